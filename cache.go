@@ -0,0 +1,349 @@
+package streams
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"iter"
+	"os"
+	"sync"
+)
+
+// --- Cached and Replayable Streams ---
+//
+// Streams over readers and channels are single-pass: iterating them a second time yields
+// nothing. Cache, Memoize and Replay record elements the first time they are pulled from the
+// source and serve later iterations from the recording. The source is pulled lazily and only
+// once, whichever iteration gets there first, so concurrent readers share a single pass.
+
+// Codec encodes elements for spilling a cache to disk.
+type Codec[T any] struct {
+	Encode func(T) ([]byte, error)
+	Decode func([]byte) (T, error)
+}
+
+// JSONCodec returns a Codec that encodes elements with encoding/json.
+func JSONCodec[T any]() Codec[T] {
+	return Codec[T]{
+		Encode: func(v T) ([]byte, error) { return json.Marshal(v) },
+		Decode: func(data []byte) (T, error) {
+			var v T
+			err := json.Unmarshal(data, &v)
+			return v, err
+		},
+	}
+}
+
+// GobCodec returns a Codec that encodes elements with encoding/gob.
+// Each element is encoded independently, including its type information.
+func GobCodec[T any]() Codec[T] {
+	return Codec[T]{
+		Encode: func(v T) ([]byte, error) {
+			var buf bytes.Buffer
+			err := gob.NewEncoder(&buf).Encode(v)
+			return buf.Bytes(), err
+		},
+		Decode: func(data []byte) (T, error) {
+			var v T
+			err := gob.NewDecoder(bytes.NewReader(data)).Decode(&v)
+			return v, err
+		},
+	}
+}
+
+// CacheConfig holds configuration for Cache.
+type CacheConfig[T any] struct {
+	Codec          Codec[T] // Codec for spilled elements; spilling is disabled if Encode is nil
+	SpillThreshold int      // Number of elements kept in memory before spilling to a temp file
+}
+
+// CacheOption is a function that modifies CacheConfig.
+type CacheOption[T any] func(*CacheConfig[T])
+
+// WithSpill keeps the first threshold elements in memory and writes the rest to a
+// temporary file using codec. The file is removed by Close.
+func WithSpill[T any](codec Codec[T], threshold int) CacheOption[T] {
+	return func(c *CacheConfig[T]) {
+		if codec.Encode != nil && codec.Decode != nil && threshold >= 0 {
+			c.Codec = codec
+			c.SpillThreshold = threshold
+		}
+	}
+}
+
+// spillSpan locates one spilled element in the spill file.
+type spillSpan struct {
+	offset int64
+	length int
+}
+
+// streamCache records the elements of a source for repeated, concurrent iteration.
+type streamCache[T any] struct {
+	src     Stream[T]
+	next    func() (T, bool)
+	stop    func()
+	pullMu  sync.Mutex // serializes pulls from the source
+	mu      sync.Mutex // guards the fields below
+	mem     []T        // in-memory elements; a ring of size limit when limit >= 0
+	count   int        // total number of elements recorded
+	limit   int        // number of most recent elements retained (< 0 = all)
+	pulling bool       // a pull is waiting on the source
+	done    bool
+	closed  bool
+	err     error
+	// spilling
+	codec     Codec[T]
+	threshold int
+	file      *os.File
+	spans     []spillSpan
+	size      int64
+}
+
+func newStreamCache[T any](s Stream[T], limit int) *streamCache[T] {
+	return &streamCache[T]{src: s, limit: limit}
+}
+
+// oldest returns the index of the oldest retained element.
+func (c *streamCache[T]) oldest() int {
+	if c.limit < 0 {
+		return 0
+	}
+	return max(c.count-c.limit, 0)
+}
+
+// get returns the element at index i, or the oldest retained element if i has been evicted,
+// pulling from the source if needed. It returns the index actually read.
+func (c *streamCache[T]) get(i int) (T, int, bool) {
+	for {
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			var zero T
+			return zero, i, false
+		}
+		i = max(i, c.oldest())
+		if i < c.count {
+			v, ok := c.load(i)
+			c.mu.Unlock()
+			return v, i, ok
+		}
+		if c.done {
+			c.mu.Unlock()
+			var zero T
+			return zero, i, false
+		}
+		seen := c.count
+		c.mu.Unlock()
+		if v, ok := c.pull(seen); ok {
+			return v, seen, true
+		}
+	}
+}
+
+// pull records the next source element and returns it, unless another reader already
+// pulled since seen or the source is exhausted. The puller gets the element directly,
+// so it is delivered even if it is not retained.
+func (c *streamCache[T]) pull(seen int) (T, bool) {
+	var zero T
+	c.pullMu.Lock()
+	defer c.pullMu.Unlock()
+
+	c.mu.Lock()
+	if c.done || c.count != seen {
+		c.mu.Unlock()
+		return zero, false
+	}
+	if c.next == nil {
+		c.next, c.stop = iter.Pull(c.src.seq)
+	}
+	c.pulling = true
+	c.mu.Unlock()
+
+	v, ok := c.next()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pulling = false
+	if c.done {
+		// Closed while waiting on the source, which could not be stopped until now
+		c.stop()
+		return zero, false
+	}
+	if !ok {
+		c.finish()
+		return zero, false
+	}
+	if err := c.store(v); err != nil {
+		c.err = err
+		c.finish()
+		return zero, false
+	}
+	return v, true
+}
+
+// finish marks the cache complete and releases the source, or leaves that to the
+// pending pull if one is waiting on the source. Must hold mu.
+func (c *streamCache[T]) finish() {
+	c.done = true
+	if c.stop != nil && !c.pulling {
+		c.stop()
+	}
+}
+
+// store appends v to the recording. Must hold mu.
+func (c *streamCache[T]) store(v T) error {
+	switch {
+	case c.limit >= 0:
+		if c.limit > 0 {
+			if len(c.mem) < c.limit {
+				c.mem = append(c.mem, v)
+			} else {
+				c.mem[c.count%c.limit] = v
+			}
+		}
+	case c.codec.Encode != nil && c.count >= c.threshold:
+		data, err := c.codec.Encode(v)
+		if err != nil {
+			return err
+		}
+		if c.file == nil {
+			if c.file, err = os.CreateTemp("", "streams-cache-*"); err != nil {
+				return err
+			}
+		}
+		if _, err := c.file.WriteAt(data, c.size); err != nil {
+			return err
+		}
+		c.spans = append(c.spans, spillSpan{offset: c.size, length: len(data)})
+		c.size += int64(len(data))
+	default:
+		c.mem = append(c.mem, v)
+	}
+	c.count++
+	return nil
+}
+
+// load returns the recorded element at index i. Must hold mu.
+func (c *streamCache[T]) load(i int) (T, bool) {
+	if c.limit >= 0 {
+		return c.mem[i%c.limit], true
+	}
+	if i < len(c.mem) {
+		return c.mem[i], true
+	}
+	span := c.spans[i-len(c.mem)]
+	data := make([]byte, span.length)
+	if _, err := c.file.ReadAt(data, span.offset); err != nil {
+		c.err = err
+		var zero T
+		return zero, false
+	}
+	v, err := c.codec.Decode(data)
+	if err != nil {
+		c.err = err
+		return v, false
+	}
+	return v, true
+}
+
+// stream returns a Stream that reads the recording from the oldest retained element.
+func (c *streamCache[T]) stream() Stream[T] {
+	return Stream[T]{
+		seq: func(yield func(T) bool) {
+			for i := 0; ; i++ {
+				v, at, ok := c.get(i)
+				if !ok || !yield(v) {
+					return
+				}
+				i = at
+			}
+		},
+	}
+}
+
+// close releases the source and removes the spill file. It does not wait for a pull
+// that is blocked in the source; that pull releases the source once it returns.
+func (c *streamCache[T]) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	c.finish()
+	c.mem = nil
+	if c.file == nil {
+		return nil
+	}
+	return errors.Join(c.file.Close(), os.Remove(c.file.Name()))
+}
+
+// CachedStream is a Stream that records its source on first iteration and replays it on
+// later iterations. It is safe for concurrent iteration. Close must be called to release
+// the source and remove any spill file.
+type CachedStream[T any] struct {
+	Stream[T]
+	cache *streamCache[T]
+}
+
+// Close releases the source and removes the spill file, if any.
+// Iterating after Close yields nothing. Close does not wait for an iteration that is
+// blocked in the source (e.g. a channel with no sender); that iteration ends, and the
+// source is released, once the source yields or ends.
+func (c *CachedStream[T]) Close() error {
+	return c.cache.close()
+}
+
+// Len returns the number of elements recorded so far.
+func (c *CachedStream[T]) Len() int {
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+	return c.cache.count
+}
+
+// Err returns the first error encountered while spilling or reading back spilled elements.
+// After such an error, iterations end early.
+func (c *CachedStream[T]) Err() error {
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+	return c.cache.err
+}
+
+// Cache returns a CachedStream that pulls s at most once, recording each element as it is
+// first reached, and serves every iteration from the recording. Iterations may run
+// concurrently and may stop early; a later iteration continues pulling where the source
+// left off. Elements are kept in memory unless WithSpill is given.
+//
+//	lines := Cache(FromReaderLines(r), WithSpill(JSONCodec[string](), 10_000))
+//	defer lines.Close()
+//	total := lines.Count()
+//	errors := lines.Filter(isError).Collect() // served from the recording
+func Cache[T any](s Stream[T], opts ...CacheOption[T]) *CachedStream[T] {
+	var cfg CacheConfig[T]
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	c := newStreamCache(s, -1)
+	c.codec = cfg.Codec
+	c.threshold = cfg.SpillThreshold
+	return &CachedStream[T]{Stream: c.stream(), cache: c}
+}
+
+// Memoize returns a Stream that records s in memory on first iteration and replays it on
+// later iterations. It is Cache without spilling or Close; the recording lives as long as
+// the returned stream, and a source that is never exhausted is never released.
+func Memoize[T any](s Stream[T]) Stream[T] {
+	return newStreamCache(s, -1).stream()
+}
+
+// Replay returns a CachedStream that shares one pass over s and retains only the last lastN
+// elements. Each iteration first yields the retained history, then continues with new
+// source elements, which are recorded for later iterations. A reader that falls more than
+// lastN elements behind concurrent readers skips to the oldest retained element.
+// If lastN <= 0, nothing is retained and each iteration continues where the source left off.
+// Call Close to release a source that is not read to the end.
+func Replay[T any](s Stream[T], lastN int) *CachedStream[T] {
+	c := newStreamCache(s, max(lastN, 0))
+	return &CachedStream[T]{Stream: c.stream(), cache: c}
+}
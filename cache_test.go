@@ -0,0 +1,224 @@
+package streams
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// firstN collects the first n elements of s without pulling any further.
+func firstN[T any](s Stream[T], n int) []T {
+	var result []T
+	for v := range s.Seq() {
+		result = append(result, v)
+		if len(result) == n {
+			break
+		}
+	}
+	return result
+}
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ReplaysSinglePassSource", func(t *testing.T) {
+		t.Parallel()
+		lines := Cache(FromReaderLines(strings.NewReader("a\nb\nc")))
+		defer lines.Close()
+		assert.Equal(t, []string{"a", "b", "c"}, lines.Collect())
+		assert.Equal(t, []string{"a", "b", "c"}, lines.Collect(), "Second iteration should replay the recording")
+		assert.Equal(t, 3, lines.Len())
+	})
+
+	t.Run("PullsLazilyAndOnce", func(t *testing.T) {
+		t.Parallel()
+		pulled := 0
+		cached := Cache(Range(0, 10).Peek(func(int) { pulled++ }))
+		defer cached.Close()
+		assert.Equal(t, []int{0, 1, 2}, firstN(cached.Stream, 3))
+		assert.Equal(t, 3, pulled, "Cache should only pull what was consumed")
+		assert.Equal(t, 45, Sum(cached.Stream), "A later iteration should continue where the source left off")
+		assert.Equal(t, 45, Sum(cached.Stream))
+		assert.Equal(t, 10, pulled, "Each element should be pulled once")
+	})
+
+	t.Run("ConcurrentReaders", func(t *testing.T) {
+		t.Parallel()
+		ch := make(chan int)
+		go func() {
+			defer close(ch)
+			for i := range 1000 {
+				ch <- i
+			}
+		}()
+		cached := Cache(FromChannel(ch))
+		defer cached.Close()
+		results := make([][]int, 4)
+		var wg sync.WaitGroup
+		for i := range results {
+			wg.Go(func() { results[i] = cached.Collect() })
+		}
+		wg.Wait()
+		want := Range(0, 1000).Collect()
+		for _, got := range results {
+			assert.Equal(t, want, got, "Concurrent readers should all see every element")
+		}
+	})
+
+	t.Run("Spill", func(t *testing.T) {
+		t.Parallel()
+		type rec struct {
+			ID   int
+			Name string
+		}
+		for name, codec := range map[string]Codec[rec]{"JSON": JSONCodec[rec](), "Gob": GobCodec[rec]()} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+				src := MapTo(Range(0, 100), func(n int) rec { return rec{ID: n, Name: strings.Repeat("x", n%5)} })
+				cached := Cache(src, WithSpill(codec, 10))
+				first := cached.Collect()
+				require.Len(t, first, 100)
+				assert.Equal(t, first, cached.Collect(), "Spilled elements should be read back")
+				require.NoError(t, cached.Err())
+
+				file := cached.cache.file
+				require.NotNil(t, file, "Elements beyond the threshold should be spilled")
+				require.NoError(t, cached.Close())
+				_, err := os.Stat(file.Name())
+				assert.True(t, os.IsNotExist(err), "Close should remove the spill file")
+				assert.Empty(t, cached.Collect(), "Iterating after Close should yield nothing")
+			})
+		}
+	})
+
+	t.Run("SpillError", func(t *testing.T) {
+		t.Parallel()
+		failing := Codec[int]{
+			Encode: func(n int) ([]byte, error) {
+				if n == 5 {
+					return nil, errors.New("boom")
+				}
+				return JSONCodec[int]().Encode(n)
+			},
+			Decode: JSONCodec[int]().Decode,
+		}
+		cached := Cache(Range(0, 10), WithSpill(failing, 2))
+		defer cached.Close()
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, cached.Collect(), "Iteration should end at the spill error")
+		assert.EqualError(t, cached.Err(), "boom")
+	})
+
+	t.Run("CloseWhileReaderBlocked", func(t *testing.T) {
+		t.Parallel()
+		ch := make(chan int)
+		released := make(chan struct{})
+		src := Stream[int]{seq: func(yield func(int) bool) {
+			defer close(released)
+			for v := range ch {
+				if !yield(v) {
+					return
+				}
+			}
+		}}
+		cached := Cache(src)
+		got := make(chan []int)
+		go func() { got <- cached.Collect() }()
+		time.Sleep(20 * time.Millisecond) // let the reader block on the source
+
+		closed := make(chan error)
+		go func() { closed <- cached.Close() }()
+		select {
+		case err := <-closed:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("Close should not wait for a reader blocked in the source")
+		}
+
+		ch <- 1
+		assert.Empty(t, <-got, "A reader blocked during Close should end once the source yields")
+		select {
+		case <-released:
+		case <-time.After(time.Second):
+			t.Fatal("The source should be released once the blocked pull returns")
+		}
+	})
+
+	t.Run("SpillDirIsTemp", func(t *testing.T) {
+		t.Parallel()
+		cached := Cache(Range(0, 3), WithSpill(JSONCodec[int](), 0))
+		defer cached.Close()
+		cached.Collect()
+		require.NotNil(t, cached.cache.file)
+		assert.Equal(t, filepath.Clean(os.TempDir()), filepath.Dir(cached.cache.file.Name()))
+	})
+}
+
+func TestMemoize(t *testing.T) {
+	t.Parallel()
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	memo := Memoize(FromChannel(ch))
+	assert.Equal(t, []int{1, 2, 3}, memo.Collect())
+	assert.Equal(t, []int{1, 2, 3}, memo.Collect(), "Memoize should replay a channel source")
+	assert.Empty(t, Memoize(Empty[int]()).Collect())
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+
+	t.Run("LastN", func(t *testing.T) {
+		t.Parallel()
+		replay := Replay(Range(0, 10), 3)
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, firstN(replay.Stream, 5))
+		assert.Equal(t, []int{2, 3, 4, 5, 6, 7, 8, 9}, replay.Collect(), "Replay should yield the last n elements, then new ones")
+		assert.Equal(t, []int{7, 8, 9}, replay.Collect(), "Replay should retain only the last n elements")
+	})
+
+	t.Run("NoHistory", func(t *testing.T) {
+		t.Parallel()
+		replay := Replay(Range(0, 6), 0)
+		assert.Equal(t, []int{0, 1}, firstN(replay.Stream, 2))
+		assert.Equal(t, []int{2, 3, 4, 5}, replay.Collect(), "Without history, iterations continue the source")
+		assert.Empty(t, replay.Collect())
+	})
+
+	t.Run("LaggingReaderSkipsAhead", func(t *testing.T) {
+		t.Parallel()
+		replay := Replay(Range(0, 100), 5)
+		var got []int
+		for v := range replay.Seq() {
+			got = append(got, v)
+			if v == 0 {
+				assert.Len(t, firstN(replay.Stream, 50), 5+45, "A nested reader should see history then new elements")
+			}
+		}
+		assert.Equal(t, 0, got[0])
+		assert.Equal(t, 45, got[1], "A reader that fell behind should skip to the oldest retained element")
+		assert.Equal(t, 99, got[len(got)-1])
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		t.Parallel()
+		released := false
+		src := Stream[int]{seq: func(yield func(int) bool) {
+			defer func() { released = true }()
+			for i := 0; yield(i); i++ {
+			}
+		}}
+		replay := Replay(src, 2)
+		assert.Equal(t, []int{0, 1, 2}, firstN(replay.Stream, 3))
+		require.NoError(t, replay.Close())
+		assert.True(t, released, "Close should release a source that never ends")
+		assert.Empty(t, replay.Collect(), "Iterating after Close should yield nothing")
+	})
+}
@@ -0,0 +1,239 @@
+package streams
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// --- Channel Sinks, Fan-In and Fan-Out ---
+//
+// These helpers run streams in goroutines. They stop on context cancellation, but a
+// goroutine blocked inside its source (e.g. FromChannel on a channel nobody closes) can
+// only notice cancellation once the source yields, so prefer context-aware sources such
+// as FromChannelCtx for sources that may block.
+
+// ToChannel runs the stream in a goroutine and sends its elements to the returned channel,
+// which has the given buffer size and is closed when the stream ends or ctx is cancelled.
+// The caller must drain the channel or cancel ctx, otherwise the goroutine blocks forever.
+func ToChannel[T any](ctx context.Context, s Stream[T], buf int) <-chan T {
+	ch := make(chan T, max(buf, 0))
+	go func() {
+		defer close(ch)
+		for v := range s.seq {
+			// Prioritize context cancellation if both ctx and channel are ready.
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- v:
+			}
+		}
+	}()
+	return ch
+}
+
+// mergeWith runs produce for each of n inputs in its own goroutine and yields whatever
+// they send as it becomes available. produce must return once send returns false.
+// If the consumer stops early, ctx is cancelled and the producers are awaited.
+func mergeWith[T any](ctx context.Context, n int, produce func(ctx context.Context, i int, send func(T) bool)) Stream[T] {
+	if n == 0 {
+		return Empty[T]()
+	}
+	return Stream[T]{
+		seq: func(yield func(T) bool) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			var (
+				out = make(chan T)
+				wg  sync.WaitGroup
+			)
+			send := func(v T) bool {
+				// Prioritize context cancellation if both ctx and out are ready.
+				if ctx.Err() != nil {
+					return false
+				}
+				select {
+				case <-ctx.Done():
+					return false
+				case out <- v:
+					return true
+				}
+			}
+			for i := range n {
+				wg.Go(func() { produce(ctx, i, send) })
+			}
+			go func() {
+				wg.Wait()
+				close(out)
+			}()
+
+			for v := range out {
+				if !yield(v) {
+					cancel()
+					// Drain until every producer has exited to prevent goroutine leaks
+					for range out {
+					}
+					return
+				}
+			}
+		},
+	}
+}
+
+// Merge returns a Stream that consumes all streams concurrently, each in its own goroutine,
+// and yields their elements as they become available. Order is preserved within each input
+// but not across inputs. The stream ends when all inputs are exhausted or ctx is cancelled.
+// If the consumer stops early, the inputs are cancelled and their goroutines are awaited.
+func Merge[T any](ctx context.Context, streams ...Stream[T]) Stream[T] {
+	return mergeWith(ctx, len(streams), func(_ context.Context, i int, send func(T) bool) {
+		for v := range streams[i].seq {
+			if !send(v) {
+				return
+			}
+		}
+	})
+}
+
+// MergeChannels returns a Stream that yields values from all channels as they arrive.
+// The stream ends when all channels are closed or ctx is cancelled, and stops reading
+// from the channels as soon as the consumer stops.
+func MergeChannels[T any](ctx context.Context, chans ...<-chan T) Stream[T] {
+	return mergeWith(ctx, len(chans), func(ctx context.Context, i int, send func(T) bool) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-chans[i]:
+				if !ok || !send(v) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// fanOutBranch is one output of a fan-out.
+type fanOutBranch struct {
+	quit     chan struct{}
+	quitOnce sync.Once
+	used     atomic.Bool
+}
+
+func (b *fanOutBranch) stopped() bool {
+	select {
+	case <-b.quit:
+		return true
+	default:
+		return false
+	}
+}
+
+// fanOut distributes s over n output streams. route picks the output for an element, given
+// which outputs have stopped; it returns -1 to drop the element. An element whose output
+// stops before receiving it is routed again.
+func fanOut[T any](ctx context.Context, s Stream[T], n int, route func(v T, stopped func(int) bool) int) []Stream[T] {
+	if n <= 0 {
+		return nil
+	}
+	var (
+		chans    = make([]chan T, n)
+		branches = make([]*fanOutBranch, n)
+		active   atomic.Int32
+		start    sync.Once
+	)
+	for i := range n {
+		chans[i] = make(chan T)
+		branches[i] = &fanOutBranch{quit: make(chan struct{})}
+	}
+	active.Store(int32(n))
+
+	pump := func() {
+		defer func() {
+			for _, ch := range chans {
+				close(ch)
+			}
+		}()
+		stopped := func(i int) bool { return branches[i].stopped() }
+		for v := range s.seq {
+			if ctx.Err() != nil || active.Load() == 0 {
+				return
+			}
+		deliver:
+			// If the chosen output stops while we wait, route the element again
+			for i := route(v, stopped); i >= 0; i = route(v, stopped) {
+				select {
+				case <-ctx.Done():
+					return
+				case <-branches[i].quit:
+				case chans[i] <- v:
+					break deliver
+				}
+			}
+		}
+	}
+
+	result := make([]Stream[T], n)
+	for i := range n {
+		b, ch := branches[i], chans[i]
+		result[i] = Stream[T]{
+			seq: func(yield func(T) bool) {
+				if b.used.Swap(true) {
+					return
+				}
+				start.Do(func() { go pump() })
+				defer b.quitOnce.Do(func() {
+					close(b.quit)
+					active.Add(-1)
+				})
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case v, ok := <-ch:
+						if !ok || !yield(v) {
+							return
+						}
+					}
+				}
+			},
+		}
+	}
+	return result
+}
+
+// FanOut distributes the elements of s round-robin over n output streams, which must be
+// consumed concurrently. The source is pulled in a goroutine started when the first output
+// is iterated; handing an element to an output blocks until that output receives it.
+// An output that stops early is skipped; the source stops when it ends, when ctx is
+// cancelled, or when every output has stopped. Each output can be iterated only once.
+// If n <= 0, returns nil.
+func FanOut[T any](ctx context.Context, s Stream[T], n int) []Stream[T] {
+	next := 0
+	return fanOut(ctx, s, n, func(_ T, stopped func(int) bool) int {
+		for range n {
+			i := next
+			next = (next + 1) % n
+			if !stopped(i) {
+				return i
+			}
+		}
+		return -1
+	})
+}
+
+// FanOutBy distributes the elements of s over n output streams by key, so all elements with
+// the same key go to the same output (chosen by DefaultHash of the key). It behaves like
+// FanOut, except that elements routed to an output that stopped are dropped.
+func FanOutBy[T any, K comparable](ctx context.Context, s Stream[T], n int, keyFn func(T) K) []Stream[T] {
+	return fanOut(ctx, s, n, func(v T, stopped func(int) bool) int {
+		i := int(DefaultHash(keyFn(v)) % uint64(n))
+		if stopped(i) {
+			return -1
+		}
+		return i
+	})
+}
@@ -0,0 +1,167 @@
+package streams
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToChannel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SendsAllAndCloses", func(t *testing.T) {
+		t.Parallel()
+		ch := ToChannel(context.Background(), Range(0, 5), 2)
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, FromChannel(ch).Collect())
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := ToChannel(ctx, Iterate(0, func(n int) int { return n + 1 }), 0)
+		assert.Equal(t, 0, <-ch)
+		assert.Equal(t, 1, <-ch)
+		cancel()
+		done := make(chan struct{})
+		go func() {
+			for range ch {
+			}
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("ToChannel should close the channel after cancellation")
+		}
+	})
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AllElements", func(t *testing.T) {
+		t.Parallel()
+		result := Merge(context.Background(), Range(0, 50), Range(100, 150), Empty[int]()).Collect()
+		slices.Sort(result)
+		want := append(Range(0, 50).Collect(), Range(100, 150).Collect()...)
+		assert.Equal(t, want, result, "Merge should yield every element of every input")
+		assert.Empty(t, Merge[int](context.Background()).Collect())
+	})
+
+	t.Run("PreservesPerInputOrder", func(t *testing.T) {
+		t.Parallel()
+		result := Merge(context.Background(), Range(0, 100), Range(1000, 1100)).Collect()
+		low := FromSlice(result).Filter(func(n int) bool { return n < 1000 }).Collect()
+		assert.Equal(t, Range(0, 100).Collect(), low, "Merge should preserve order within each input")
+	})
+
+	t.Run("AsAvailable", func(t *testing.T) {
+		t.Parallel()
+		slow, fast := make(chan int), make(chan int, 1)
+		fast <- 1
+		close(fast)
+		merged := MergeChannels(context.Background(), slow, fast)
+		for v := range merged.Seq() {
+			assert.Equal(t, 1, v, "Merge should not wait for a blocked input")
+			break
+		}
+		close(slow)
+	})
+
+	t.Run("EarlyStopWithInfiniteInputs", func(t *testing.T) {
+		t.Parallel()
+		naturals := Iterate(0, func(n int) int { return n + 1 })
+		result := Merge(context.Background(), naturals, naturals).Limit(10).Collect()
+		assert.Len(t, result, 10, "Merge should cancel its inputs when the consumer stops")
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		block := make(chan int)
+		values := make(chan int)
+		done := make(chan []int)
+		go func() { done <- MergeChannels(ctx, block, values).Collect() }()
+		values <- 7
+		cancel()
+		select {
+		case got := <-done:
+			assert.Equal(t, []int{7}, got)
+		case <-time.After(time.Second):
+			t.Fatal("MergeChannels should end when ctx is cancelled")
+		}
+	})
+}
+
+func TestFanOut(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RoundRobin", func(t *testing.T) {
+		t.Parallel()
+		outs := FanOut(context.Background(), Range(0, 9), 3)
+		require.Len(t, outs, 3)
+		assert.Equal(t, [][]int{{0, 3, 6}, {1, 4, 7}, {2, 5, 8}}, collectConcurrently(outs))
+		assert.Nil(t, FanOut(context.Background(), Range(0, 9), 0))
+	})
+
+	t.Run("ByKey", func(t *testing.T) {
+		t.Parallel()
+		words := []string{"a", "bb", "c", "dd", "eee", "f", "gg"}
+		outs := FanOutBy(context.Background(), FromSlice(words), 2, func(s string) int { return len(s) })
+		results := collectConcurrently(outs)
+		byLen := make(map[int]int)
+		total := 0
+		for i, part := range results {
+			for _, w := range part {
+				if prev, ok := byLen[len(w)]; ok {
+					assert.Equal(t, prev, i, "Elements with the same key should go to the same output")
+				}
+				byLen[len(w)] = i
+				total++
+			}
+		}
+		assert.Equal(t, len(words), total)
+	})
+
+	t.Run("StoppedOutputIsSkipped", func(t *testing.T) {
+		t.Parallel()
+		outs := FanOut(context.Background(), Range(0, 100), 2)
+		var (
+			first, rest []int
+			wg          sync.WaitGroup
+		)
+		wg.Go(func() {
+			for v := range outs[0].Seq() {
+				first = append(first, v)
+				break
+			}
+		})
+		wg.Go(func() { rest = outs[1].Collect() })
+		wg.Wait()
+		assert.Equal(t, []int{0}, first)
+		assert.Len(t, rest, 99, "Round-robin should skip an output that stopped")
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		outs := FanOut(ctx, Iterate(0, func(n int) int { return n + 1 }), 2)
+		done := make(chan struct{})
+		go func() {
+			collectConcurrently(outs)
+			close(done)
+		}()
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("FanOut outputs should end when ctx is cancelled")
+		}
+	})
+}
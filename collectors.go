@@ -2,6 +2,7 @@ package streams
 
 import (
 	"cmp"
+	"maps"
 	"slices"
 	"strings"
 )
@@ -15,13 +16,32 @@ type Collector[T, A, R any] struct {
 	Accumulator func(A, T) A
 	// Finisher transforms the accumulator to the final result.
 	Finisher func(A) R
+	// Combiner merges two accumulators (optional).
+	// Collectors with a Combiner can be used with ParallelCollectTo.
+	Combiner func(A, A) A
+	// Done reports whether the accumulator needs no more elements (optional).
+	// CollectTo stops pulling from the stream once Done returns true, so short-circuiting
+	// collectors can be used on infinite streams such as Generate or Interval.
+	Done func(A) bool
+}
+
+// done reports whether acc is finished according to the collector's Done signal.
+func (c Collector[T, A, R]) done(acc A) bool {
+	return c.Done != nil && c.Done(acc)
 }
 
 // CollectTo collects stream elements using the given Collector.
+// If the collector has a Done signal, collection stops as soon as it reports true.
 func CollectTo[T, A, R any](s Stream[T], c Collector[T, A, R]) R {
 	acc := c.Supplier()
+	if c.done(acc) {
+		return c.Finisher(acc)
+	}
 	for v := range s.seq {
 		acc = c.Accumulator(acc, v)
+		if c.done(acc) {
+			break
+		}
 	}
 	return c.Finisher(acc)
 }
@@ -46,6 +66,10 @@ func ToSetCollector[T comparable]() Collector[T, map[T]struct{}, map[T]struct{}]
 			return acc
 		},
 		Finisher: func(acc map[T]struct{}) map[T]struct{} { return acc },
+		Combiner: func(a, b map[T]struct{}) map[T]struct{} {
+			maps.Copy(a, b)
+			return a
+		},
 	}
 }
 
@@ -102,6 +126,10 @@ func CountingCollector[T any]() Collector[T, *countingState, int] {
 			return cs
 		},
 		Finisher: func(cs *countingState) int { return cs.count },
+		Combiner: func(a, b *countingState) *countingState {
+			a.count += b.count
+			return a
+		},
 	}
 }
 
@@ -117,6 +145,10 @@ func SummingCollector[T Numeric]() Collector[T, *summingState[T], T] {
 			return ss
 		},
 		Finisher: func(ss *summingState[T]) T { return ss.sum },
+		Combiner: func(a, b *summingState[T]) *summingState[T] {
+			a.sum += b.sum
+			return a
+		},
 	}
 }
 
@@ -141,6 +173,11 @@ func AveragingCollector[T Numeric]() Collector[T, *averagingState, Optional[floa
 			}
 			return Some(as.sum / float64(as.count))
 		},
+		Combiner: func(a, b *averagingState) *averagingState {
+			a.sum += b.sum
+			a.count += b.count
+			return a
+		},
 	}
 }
 
@@ -173,6 +210,12 @@ func MaxByCollector[T any](cmp func(T, T) int) Collector[T, *maxState[T], Option
 			}
 			return None[T]()
 		},
+		Combiner: func(a, b *maxState[T]) *maxState[T] {
+			if b.found && (!a.found || cmp(b.max, a.max) > 0) {
+				*a = *b
+			}
+			return a
+		},
 	}
 }
 
@@ -193,6 +236,12 @@ func MinByCollector[T any](cmp func(T, T) int) Collector[T, *minState[T], Option
 			}
 			return None[T]()
 		},
+		Combiner: func(a, b *minState[T]) *minState[T] {
+			if b.found && (!a.found || cmp(b.min, a.min) < 0) {
+				*a = *b
+			}
+			return a
+		},
 	}
 }
 
@@ -235,6 +284,100 @@ func PartitioningByCollector[T any](pred func(T) bool) Collector[T, *partitionSt
 	}
 }
 
+// GroupingByWith returns a Collector that groups elements by key and collects each group
+// with the downstream collector. Only one downstream accumulator is kept per key, so
+// e.g. GroupingByWith(keyFn, SummingCollector[int]()) never stores the elements.
+// Downstreams can themselves be grouping collectors for multi-level grouping.
+// Once a key's downstream is done, further elements for that key are skipped; the grouping
+// itself is never done, since a new key may appear at any time.
+// The result is mergeable if the downstream has a Combiner.
+func GroupingByWith[T any, K comparable, A, R any](keyFn func(T) K, downstream Collector[T, A, R]) Collector[T, map[K]A, map[K]R] {
+	c := Collector[T, map[K]A, map[K]R]{
+		Supplier: func() map[K]A { return make(map[K]A) },
+		Accumulator: func(m map[K]A, v T) map[K]A {
+			k := keyFn(v)
+			acc, ok := m[k]
+			if !ok {
+				acc = downstream.Supplier()
+			} else if downstream.done(acc) {
+				return m
+			}
+			m[k] = downstream.Accumulator(acc, v)
+			return m
+		},
+		Finisher: func(m map[K]A) map[K]R {
+			result := make(map[K]R, len(m))
+			for k, acc := range m {
+				result[k] = downstream.Finisher(acc)
+			}
+			return result
+		},
+	}
+	if downstream.Combiner != nil {
+		c.Combiner = func(a, b map[K]A) map[K]A {
+			for k, acc := range b {
+				if cur, ok := a[k]; ok {
+					a[k] = downstream.Combiner(cur, acc)
+				} else {
+					a[k] = acc
+				}
+			}
+			return a
+		}
+	}
+	return c
+}
+
+// partitionWithState holds the downstream accumulators for PartitioningByWith.
+type partitionWithState[A any] struct {
+	trueAcc, falseAcc A
+}
+
+// PartitioningByWith returns a Collector that partitions elements by a predicate and
+// collects each partition with the downstream collector.
+// The result always contains both the true and false keys.
+// Done partitions are skipped, and the collector is done once both partitions are done.
+// The result is mergeable if the downstream has a Combiner.
+func PartitioningByWith[T, A, R any](pred func(T) bool, downstream Collector[T, A, R]) Collector[T, *partitionWithState[A], map[bool]R] {
+	c := Collector[T, *partitionWithState[A], map[bool]R]{
+		Supplier: func() *partitionWithState[A] {
+			return &partitionWithState[A]{
+				trueAcc:  downstream.Supplier(),
+				falseAcc: downstream.Supplier(),
+			}
+		},
+		Accumulator: func(ps *partitionWithState[A], v T) *partitionWithState[A] {
+			if pred(v) {
+				if !downstream.done(ps.trueAcc) {
+					ps.trueAcc = downstream.Accumulator(ps.trueAcc, v)
+				}
+			} else if !downstream.done(ps.falseAcc) {
+				ps.falseAcc = downstream.Accumulator(ps.falseAcc, v)
+			}
+			return ps
+		},
+		Finisher: func(ps *partitionWithState[A]) map[bool]R {
+			return map[bool]R{
+				true:  downstream.Finisher(ps.trueAcc),
+				false: downstream.Finisher(ps.falseAcc),
+			}
+		},
+	}
+	if downstream.Combiner != nil {
+		c.Combiner = func(a, b *partitionWithState[A]) *partitionWithState[A] {
+			a.trueAcc = downstream.Combiner(a.trueAcc, b.trueAcc)
+			a.falseAcc = downstream.Combiner(a.falseAcc, b.falseAcc)
+			return a
+		}
+	}
+	if downstream.Done != nil {
+		c.Done = func(ps *partitionWithState[A]) bool {
+			return downstream.Done(ps.trueAcc) && downstream.Done(ps.falseAcc)
+		}
+	}
+	return c
+}
+
 // ToMapCollector returns a Collector that creates a map from elements.
 func ToMapCollector[T any, K comparable, V any](keyFn func(T) K, valFn func(T) V) Collector[T, map[K]V, map[K]V] {
 	return Collector[T, map[K]V, map[K]V]{
@@ -276,6 +419,7 @@ type firstState[T any] struct {
 }
 
 // FirstCollector returns a Collector that returns the first element.
+// It is done after the first element, so it also works on infinite streams.
 func FirstCollector[T any]() Collector[T, *firstState[T], Optional[T]] {
 	return Collector[T, *firstState[T], Optional[T]]{
 		Supplier: func() *firstState[T] { return &firstState[T]{} },
@@ -292,6 +436,7 @@ func FirstCollector[T any]() Collector[T, *firstState[T], Optional[T]] {
 			}
 			return None[T]()
 		},
+		Done: func(fs *firstState[T]) bool { return fs.found },
 	}
 }
 
@@ -342,6 +487,8 @@ func MappingCollector[T, U, A, R any](mapper func(T) U, downstream Collector[U,
 			return downstream.Accumulator(acc, mapper(v))
 		},
 		Finisher: downstream.Finisher,
+		Combiner: downstream.Combiner,
+		Done:     downstream.Done,
 	}
 }
 
@@ -356,6 +503,8 @@ func FilteringCollector[T, A, R any](pred func(T) bool, downstream Collector[T,
 			return acc
 		},
 		Finisher: downstream.Finisher,
+		Combiner: downstream.Combiner,
+		Done:     downstream.Done,
 	}
 }
 
@@ -366,10 +515,15 @@ func FlatMappingCollector[T, U, A, R any](mapper func(T) Stream[U], downstream C
 		Accumulator: func(acc A, v T) A {
 			for u := range mapper(v).seq {
 				acc = downstream.Accumulator(acc, u)
+				if downstream.done(acc) {
+					break
+				}
 			}
 			return acc
 		},
 		Finisher: downstream.Finisher,
+		Combiner: downstream.Combiner,
+		Done:     downstream.Done,
 	}
 }
 
@@ -380,12 +534,15 @@ type teeingState[A1, A2 any] struct {
 }
 
 // TeeingCollector combines the results of two collectors.
+// The result is mergeable if both collectors have a Combiner.
+// A sub-collector that is done receives no further elements, and the teeing collector
+// is done once both sub-collectors are done.
 func TeeingCollector[T, A1, R1, A2, R2, R any](
 	c1 Collector[T, A1, R1],
 	c2 Collector[T, A2, R2],
 	merger func(R1, R2) R,
 ) Collector[T, *teeingState[A1, A2], R] {
-	return Collector[T, *teeingState[A1, A2], R]{
+	c := Collector[T, *teeingState[A1, A2], R]{
 		Supplier: func() *teeingState[A1, A2] {
 			return &teeingState[A1, A2]{
 				acc1: c1.Supplier(),
@@ -393,14 +550,369 @@ func TeeingCollector[T, A1, R1, A2, R2, R any](
 			}
 		},
 		Accumulator: func(ts *teeingState[A1, A2], v T) *teeingState[A1, A2] {
-			ts.acc1 = c1.Accumulator(ts.acc1, v)
-			ts.acc2 = c2.Accumulator(ts.acc2, v)
+			if !c1.done(ts.acc1) {
+				ts.acc1 = c1.Accumulator(ts.acc1, v)
+			}
+			if !c2.done(ts.acc2) {
+				ts.acc2 = c2.Accumulator(ts.acc2, v)
+			}
 			return ts
 		},
 		Finisher: func(ts *teeingState[A1, A2]) R {
 			return merger(c1.Finisher(ts.acc1), c2.Finisher(ts.acc2))
 		},
 	}
+	if c1.Combiner != nil && c2.Combiner != nil {
+		c.Combiner = func(a, b *teeingState[A1, A2]) *teeingState[A1, A2] {
+			a.acc1 = c1.Combiner(a.acc1, b.acc1)
+			a.acc2 = c2.Combiner(a.acc2, b.acc2)
+			return a
+		}
+	}
+	if c1.Done != nil && c2.Done != nil {
+		c.Done = func(ts *teeingState[A1, A2]) bool {
+			return c1.Done(ts.acc1) && c2.Done(ts.acc2)
+		}
+	}
+	return c
+}
+
+// teeing3State holds state for Teeing3Collector.
+type teeing3State[A1, A2, A3 any] struct {
+	acc1 A1
+	acc2 A2
+	acc3 A3
+}
+
+// Teeing3Collector combines the results of three collectors in one pass.
+// Like TeeingCollector, done sub-collectors receive no further elements.
+func Teeing3Collector[T, A1, R1, A2, R2, A3, R3, R any](
+	c1 Collector[T, A1, R1],
+	c2 Collector[T, A2, R2],
+	c3 Collector[T, A3, R3],
+	merger func(R1, R2, R3) R,
+) Collector[T, *teeing3State[A1, A2, A3], R] {
+	c := Collector[T, *teeing3State[A1, A2, A3], R]{
+		Supplier: func() *teeing3State[A1, A2, A3] {
+			return &teeing3State[A1, A2, A3]{
+				acc1: c1.Supplier(),
+				acc2: c2.Supplier(),
+				acc3: c3.Supplier(),
+			}
+		},
+		Accumulator: func(ts *teeing3State[A1, A2, A3], v T) *teeing3State[A1, A2, A3] {
+			if !c1.done(ts.acc1) {
+				ts.acc1 = c1.Accumulator(ts.acc1, v)
+			}
+			if !c2.done(ts.acc2) {
+				ts.acc2 = c2.Accumulator(ts.acc2, v)
+			}
+			if !c3.done(ts.acc3) {
+				ts.acc3 = c3.Accumulator(ts.acc3, v)
+			}
+			return ts
+		},
+		Finisher: func(ts *teeing3State[A1, A2, A3]) R {
+			return merger(c1.Finisher(ts.acc1), c2.Finisher(ts.acc2), c3.Finisher(ts.acc3))
+		},
+	}
+	if c1.Combiner != nil && c2.Combiner != nil && c3.Combiner != nil {
+		c.Combiner = func(a, b *teeing3State[A1, A2, A3]) *teeing3State[A1, A2, A3] {
+			a.acc1 = c1.Combiner(a.acc1, b.acc1)
+			a.acc2 = c2.Combiner(a.acc2, b.acc2)
+			a.acc3 = c3.Combiner(a.acc3, b.acc3)
+			return a
+		}
+	}
+	if c1.Done != nil && c2.Done != nil && c3.Done != nil {
+		c.Done = func(ts *teeing3State[A1, A2, A3]) bool {
+			return c1.Done(ts.acc1) && c2.Done(ts.acc2) && c3.Done(ts.acc3)
+		}
+	}
+	return c
+}
+
+// teeing4State holds state for Teeing4Collector.
+type teeing4State[A1, A2, A3, A4 any] struct {
+	acc1 A1
+	acc2 A2
+	acc3 A3
+	acc4 A4
+}
+
+// Teeing4Collector combines the results of four collectors in one pass.
+// Like TeeingCollector, done sub-collectors receive no further elements.
+func Teeing4Collector[T, A1, R1, A2, R2, A3, R3, A4, R4, R any](
+	c1 Collector[T, A1, R1],
+	c2 Collector[T, A2, R2],
+	c3 Collector[T, A3, R3],
+	c4 Collector[T, A4, R4],
+	merger func(R1, R2, R3, R4) R,
+) Collector[T, *teeing4State[A1, A2, A3, A4], R] {
+	c := Collector[T, *teeing4State[A1, A2, A3, A4], R]{
+		Supplier: func() *teeing4State[A1, A2, A3, A4] {
+			return &teeing4State[A1, A2, A3, A4]{
+				acc1: c1.Supplier(),
+				acc2: c2.Supplier(),
+				acc3: c3.Supplier(),
+				acc4: c4.Supplier(),
+			}
+		},
+		Accumulator: func(ts *teeing4State[A1, A2, A3, A4], v T) *teeing4State[A1, A2, A3, A4] {
+			if !c1.done(ts.acc1) {
+				ts.acc1 = c1.Accumulator(ts.acc1, v)
+			}
+			if !c2.done(ts.acc2) {
+				ts.acc2 = c2.Accumulator(ts.acc2, v)
+			}
+			if !c3.done(ts.acc3) {
+				ts.acc3 = c3.Accumulator(ts.acc3, v)
+			}
+			if !c4.done(ts.acc4) {
+				ts.acc4 = c4.Accumulator(ts.acc4, v)
+			}
+			return ts
+		},
+		Finisher: func(ts *teeing4State[A1, A2, A3, A4]) R {
+			return merger(c1.Finisher(ts.acc1), c2.Finisher(ts.acc2), c3.Finisher(ts.acc3), c4.Finisher(ts.acc4))
+		},
+	}
+	if c1.Combiner != nil && c2.Combiner != nil && c3.Combiner != nil && c4.Combiner != nil {
+		c.Combiner = func(a, b *teeing4State[A1, A2, A3, A4]) *teeing4State[A1, A2, A3, A4] {
+			a.acc1 = c1.Combiner(a.acc1, b.acc1)
+			a.acc2 = c2.Combiner(a.acc2, b.acc2)
+			a.acc3 = c3.Combiner(a.acc3, b.acc3)
+			a.acc4 = c4.Combiner(a.acc4, b.acc4)
+			return a
+		}
+	}
+	if c1.Done != nil && c2.Done != nil && c3.Done != nil && c4.Done != nil {
+		c.Done = func(ts *teeing4State[A1, A2, A3, A4]) bool {
+			return c1.Done(ts.acc1) && c2.Done(ts.acc2) && c3.Done(ts.acc3) && c4.Done(ts.acc4)
+		}
+	}
+	return c
+}
+
+// --- Composite Collectors ---
+
+// compositeField is one type-erased collector of a CompositeCollector with its accumulator.
+type compositeField[T, S any] interface {
+	accumulate(v T)
+	done() bool
+	merge(other compositeField[T, S])
+	finish(dst *S)
+}
+
+// compositeFieldOf binds a collector, its accumulator and the setter that stores its result.
+type compositeFieldOf[T, A, R, S any] struct {
+	c   Collector[T, A, R]
+	acc A
+	set func(*S, R)
+}
+
+func (f *compositeFieldOf[T, A, R, S]) accumulate(v T) {
+	if !f.c.done(f.acc) {
+		f.acc = f.c.Accumulator(f.acc, v)
+	}
+}
+
+func (f *compositeFieldOf[T, A, R, S]) done() bool { return f.c.done(f.acc) }
+
+func (f *compositeFieldOf[T, A, R, S]) merge(other compositeField[T, S]) {
+	f.acc = f.c.Combiner(f.acc, other.(*compositeFieldOf[T, A, R, S]).acc)
+}
+
+func (f *compositeFieldOf[T, A, R, S]) finish(dst *S) { f.set(dst, f.c.Finisher(f.acc)) }
+
+// compositeState holds the field accumulators of a CompositeCollector.
+type compositeState[T, S any] struct {
+	fields []compositeField[T, S]
+}
+
+// CompositeCollector builds a Collector that runs any number of heterogeneous collectors
+// in one pass and stores each result into a field of a struct S, without reflection.
+// Create it with NewCompositeCollector, add collectors with AddField and finish with Build:
+//
+//	type summary struct {
+//		Count    int
+//		Sum      float64
+//		Min, Max Optional[float64]
+//	}
+//	b := NewCompositeCollector[float64, summary]()
+//	AddField(b, CountingCollector[float64](), func(s *summary, n int) { s.Count = n })
+//	AddField(b, SummingCollector[float64](), func(s *summary, v float64) { s.Sum = v })
+//	res := CollectTo(values, b.Build())
+type CompositeCollector[T, S any] struct {
+	fields    []func() compositeField[T, S]
+	mergeable bool
+	stoppable bool
+}
+
+// NewCompositeCollector returns an empty CompositeCollector producing values of type S.
+func NewCompositeCollector[T, S any]() *CompositeCollector[T, S] {
+	return &CompositeCollector[T, S]{mergeable: true, stoppable: true}
+}
+
+// AddField adds a collector whose result is stored into S by set, and returns b for chaining.
+// It is a function rather than a method because Go methods cannot declare type parameters.
+func AddField[T, S, A, R any](b *CompositeCollector[T, S], c Collector[T, A, R], set func(*S, R)) *CompositeCollector[T, S] {
+	b.fields = append(b.fields, func() compositeField[T, S] {
+		return &compositeFieldOf[T, A, R, S]{c: c, acc: c.Supplier(), set: set}
+	})
+	b.mergeable = b.mergeable && c.Combiner != nil
+	b.stoppable = b.stoppable && c.Done != nil
+	return b
+}
+
+// Build returns the Collector. Done fields receive no further elements.
+// The collector has a Combiner if every field's collector has one, and a Done signal
+// (true once every field is done) if every field's collector has one.
+// Later AddField calls do not affect collectors already built.
+func (b *CompositeCollector[T, S]) Build() Collector[T, *compositeState[T, S], S] {
+	fields := slices.Clone(b.fields)
+	c := Collector[T, *compositeState[T, S], S]{
+		Supplier: func() *compositeState[T, S] {
+			st := &compositeState[T, S]{fields: make([]compositeField[T, S], len(fields))}
+			for i, newField := range fields {
+				st.fields[i] = newField()
+			}
+			return st
+		},
+		Accumulator: func(st *compositeState[T, S], v T) *compositeState[T, S] {
+			for _, f := range st.fields {
+				f.accumulate(v)
+			}
+			return st
+		},
+		Finisher: func(st *compositeState[T, S]) S {
+			var result S
+			for _, f := range st.fields {
+				f.finish(&result)
+			}
+			return result
+		},
+	}
+	if b.mergeable {
+		c.Combiner = func(a, other *compositeState[T, S]) *compositeState[T, S] {
+			for i, f := range a.fields {
+				f.merge(other.fields[i])
+			}
+			return a
+		}
+	}
+	if b.stoppable && len(fields) > 0 {
+		c.Done = func(st *compositeState[T, S]) bool {
+			for _, f := range st.fields {
+				if !f.done() {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return c
+}
+
+// --- Short-circuiting Collectors ---
+
+// limitState holds state for LimitingCollector.
+type limitState[A any] struct {
+	acc   A
+	count int
+}
+
+// LimitingCollector returns a Collector that passes at most n elements to the downstream.
+// It is done after n elements, so it can collect from infinite streams.
+func LimitingCollector[T, A, R any](n int, downstream Collector[T, A, R]) Collector[T, *limitState[A], R] {
+	n = max(n, 0)
+	return Collector[T, *limitState[A], R]{
+		Supplier: func() *limitState[A] {
+			return &limitState[A]{acc: downstream.Supplier()}
+		},
+		Accumulator: func(ls *limitState[A], v T) *limitState[A] {
+			if ls.count < n {
+				ls.acc = downstream.Accumulator(ls.acc, v)
+				ls.count++
+			}
+			return ls
+		},
+		Finisher: func(ls *limitState[A]) R { return downstream.Finisher(ls.acc) },
+		Done: func(ls *limitState[A]) bool {
+			return ls.count >= n || downstream.done(ls.acc)
+		},
+	}
+}
+
+// takeWhileState holds state for TakingWhileCollector.
+type takeWhileState[A any] struct {
+	acc     A
+	stopped bool
+}
+
+// TakingWhileCollector returns a Collector that passes elements to the downstream while
+// the predicate holds. It is done at the first element that fails the predicate.
+func TakingWhileCollector[T, A, R any](pred func(T) bool, downstream Collector[T, A, R]) Collector[T, *takeWhileState[A], R] {
+	return Collector[T, *takeWhileState[A], R]{
+		Supplier: func() *takeWhileState[A] {
+			return &takeWhileState[A]{acc: downstream.Supplier()}
+		},
+		Accumulator: func(ts *takeWhileState[A], v T) *takeWhileState[A] {
+			if ts.stopped {
+				return ts
+			}
+			if !pred(v) {
+				ts.stopped = true
+				return ts
+			}
+			ts.acc = downstream.Accumulator(ts.acc, v)
+			return ts
+		},
+		Finisher: func(ts *takeWhileState[A]) R { return downstream.Finisher(ts.acc) },
+		Done: func(ts *takeWhileState[A]) bool {
+			return ts.stopped || downstream.done(ts.acc)
+		},
+	}
+}
+
+// AnyMatchCollector returns a Collector that reports whether any element matches the predicate.
+// It is done at the first match.
+func AnyMatchCollector[T any](pred func(T) bool) Collector[T, *bool, bool] {
+	return Collector[T, *bool, bool]{
+		Supplier: func() *bool { return new(bool) },
+		Accumulator: func(found *bool, v T) *bool {
+			if !*found && pred(v) {
+				*found = true
+			}
+			return found
+		},
+		Finisher: func(found *bool) bool { return *found },
+		Combiner: func(a, b *bool) *bool {
+			*a = *a || *b
+			return a
+		},
+		Done: func(found *bool) bool { return *found },
+	}
+}
+
+// AllMatchCollector returns a Collector that reports whether all elements match the predicate.
+// It is done at the first mismatch. An empty stream yields true.
+func AllMatchCollector[T any](pred func(T) bool) Collector[T, *bool, bool] {
+	return Collector[T, *bool, bool]{
+		Supplier: func() *bool { return new(bool) },
+		Accumulator: func(failed *bool, v T) *bool {
+			if !*failed && !pred(v) {
+				*failed = true
+			}
+			return failed
+		},
+		Finisher: func(failed *bool) bool { return !*failed },
+		Combiner: func(a, b *bool) *bool {
+			*a = *a || *b
+			return a
+		},
+		Done: func(failed *bool) bool { return *failed },
+	}
 }
 
 // --- TopK and BottomK Collectors ---
@@ -441,6 +953,19 @@ func (s *topKState[T]) heapifyDown(i int) {
 	}
 }
 
+// push offers v to the heap, replacing the smallest element if the heap is full.
+func (s *topKState[T]) push(v T) {
+	if len(s.heap) < s.k {
+		// Heap not full, just add
+		s.heap = append(s.heap, v)
+		s.heapifyUp(len(s.heap) - 1)
+	} else if s.k > 0 && !s.less(v, s.heap[0]) {
+		// v is larger than min in heap, replace
+		s.heap[0] = v
+		s.heapifyDown(0)
+	}
+}
+
 func (s *topKState[T]) heapifyUp(i int) {
 	for i > 0 {
 		parent := (i - 1) / 2
@@ -465,15 +990,7 @@ func TopKCollector[T any](k int, less func(T, T) bool) Collector[T, *topKState[T
 			}
 		},
 		Accumulator: func(s *topKState[T], v T) *topKState[T] {
-			if len(s.heap) < s.k {
-				// Heap not full, just add
-				s.heap = append(s.heap, v)
-				s.heapifyUp(len(s.heap) - 1)
-			} else if !s.less(v, s.heap[0]) {
-				// v is larger than min in heap, replace
-				s.heap[0] = v
-				s.heapifyDown(0)
-			}
+			s.push(v)
 			return s
 		},
 		Finisher: func(s *topKState[T]) []T {
@@ -491,6 +1008,12 @@ func TopKCollector[T any](k int, less func(T, T) bool) Collector[T, *topKState[T
 			})
 			return result
 		},
+		Combiner: func(a, b *topKState[T]) *topKState[T] {
+			for _, v := range b.heap {
+				a.push(v)
+			}
+			return a
+		},
 	}
 }
 
@@ -516,6 +1039,18 @@ func (s *bottomKState[T]) heapifyDown(i int) {
 	}
 }
 
+// push offers v to the heap, replacing the largest element if the heap is full.
+func (s *bottomKState[T]) push(v T) {
+	if len(s.heap) < s.k {
+		s.heap = append(s.heap, v)
+		s.heapifyUp(len(s.heap) - 1)
+	} else if s.k > 0 && s.less(v, s.heap[0]) {
+		// v is smaller than max in heap, replace
+		s.heap[0] = v
+		s.heapifyDown(0)
+	}
+}
+
 func (s *bottomKState[T]) heapifyUp(i int) {
 	for i > 0 {
 		parent := (i - 1) / 2
@@ -539,14 +1074,7 @@ func BottomKCollector[T any](k int, less func(T, T) bool) Collector[T, *bottomKS
 			}
 		},
 		Accumulator: func(s *bottomKState[T], v T) *bottomKState[T] {
-			if len(s.heap) < s.k {
-				s.heap = append(s.heap, v)
-				s.heapifyUp(len(s.heap) - 1)
-			} else if s.less(v, s.heap[0]) {
-				// v is smaller than max in heap, replace
-				s.heap[0] = v
-				s.heapifyDown(0)
-			}
+			s.push(v)
 			return s
 		},
 		Finisher: func(s *bottomKState[T]) []T {
@@ -563,6 +1091,12 @@ func BottomKCollector[T any](k int, less func(T, T) bool) Collector[T, *bottomKS
 			})
 			return result
 		},
+		Combiner: func(a, b *bottomKState[T]) *bottomKState[T] {
+			for _, v := range b.heap {
+				a.push(v)
+			}
+			return a
+		},
 	}
 }
 
@@ -627,6 +1161,10 @@ func QuantileCollector[T any](q float64, less func(T, T) bool) Collector[T, *qua
 
 			return Some(sorted[idx])
 		},
+		Combiner: func(a, b *quantileState[T]) *quantileState[T] {
+			a.elements = append(a.elements, b.elements...)
+			return a
+		},
 	}
 }
 
@@ -656,6 +1194,12 @@ func FrequencyCollector[T comparable]() Collector[T, map[T]int, map[T]int] {
 			return m
 		},
 		Finisher: func(m map[T]int) map[T]int { return m },
+		Combiner: func(a, b map[T]int) map[T]int {
+			for v, n := range b {
+				a[v] += n
+			}
+			return a
+		},
 	}
 }
 
@@ -712,3 +1256,68 @@ func HistogramCollector[T any, K comparable](keyFn func(T) K) Collector[T, *hist
 		},
 	}
 }
+
+// --- Running Collectors ---
+
+// CollectRunning returns a lazy stream that applies the collector incrementally and yields
+// the finished result after every `every` elements, plus once more at the end if elements
+// arrived since the last emission. Use every = 1 to emit after each element.
+// The Finisher is called once per emission, so it must not invalidate the accumulator;
+// results may share state with it (e.g. ToSliceCollector), so copy them if you keep them.
+// The stream ends early once the collector is done. If every <= 0, returns an empty stream.
+func CollectRunning[T, A, R any](s Stream[T], c Collector[T, A, R], every int) Stream[R] {
+	if every <= 0 {
+		return Empty[R]()
+	}
+	return Stream[R]{
+		seq: func(yield func(R) bool) {
+			acc := c.Supplier()
+			if c.done(acc) {
+				return
+			}
+			pending := 0
+			for v := range s.seq {
+				acc = c.Accumulator(acc, v)
+				pending++
+				done := c.done(acc)
+				if pending == every || done {
+					pending = 0
+					if !yield(c.Finisher(acc)) || done {
+						return
+					}
+				}
+			}
+			if pending > 0 {
+				yield(c.Finisher(acc))
+			}
+		},
+	}
+}
+
+// CollectRunningByKey returns a lazy Stream2 that groups values by key, collects each group
+// with the collector, and yields the key with its updated result after every element,
+// like a changelog of a materialized GroupingByWith view.
+// One accumulator is kept per key; the same Finisher caveats as CollectRunning apply.
+// Values for a key whose collector is done are skipped.
+func CollectRunningByKey[K comparable, V, A, R any](s Stream2[K, V], c Collector[V, A, R]) Stream2[K, R] {
+	return Stream2[K, R]{
+		seq: func(yield func(K, R) bool) {
+			accs := make(map[K]A)
+			for k, v := range s.seq {
+				acc, ok := accs[k]
+				if !ok {
+					acc = c.Supplier()
+				}
+				if c.done(acc) {
+					accs[k] = acc
+					continue
+				}
+				acc = c.Accumulator(acc, v)
+				accs[k] = acc
+				if !yield(k, c.Finisher(acc)) {
+					return
+				}
+			}
+		},
+	}
+}
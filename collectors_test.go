@@ -1,9 +1,11 @@
 package streams
 
 import (
+	"cmp"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestCollectors tests Collector operations.
@@ -195,6 +197,14 @@ func TestCompositeCollectors(t *testing.T) {
 		assert.Equal(t, []int{1, 10, 2, 20, 3, 30}, result, "FlatMappingCollector should flatten before collecting")
 	})
 
+	t.Run("CombinerPropagation", func(t *testing.T) {
+		t.Parallel()
+		c := ApproxDistinctCountCollector[int](0, nil)
+		assert.NotNil(t, MappingCollector(func(s string) int { return len(s) }, c).Combiner, "MappingCollector should keep the downstream Combiner")
+		assert.NotNil(t, FilteringCollector(func(int) bool { return true }, c).Combiner, "FilteringCollector should keep the downstream Combiner")
+		assert.NotNil(t, FlatMappingCollector(func(n int) Stream[int] { return Of(n) }, c).Combiner, "FlatMappingCollector should keep the downstream Combiner")
+	})
+
 	t.Run("TeeingCollector", func(t *testing.T) {
 		t.Parallel()
 		result := CollectTo(Of(1, 2, 3, 4, 5), TeeingCollector(
@@ -424,3 +434,316 @@ func TestQuantileCollectorUnsorted(t *testing.T) {
 		assert.True(t, result.Get() >= 2 && result.Get() <= 3, "25th percentile should be 2 or 3")
 	})
 }
+
+// TestGroupingWithDownstream tests grouping and partitioning with downstream collectors.
+func TestGroupingWithDownstream(t *testing.T) {
+	t.Parallel()
+	type order struct {
+		Customer string
+		Region   string
+		Amount   int
+	}
+	orders := []order{
+		{"alice", "eu", 10},
+		{"bob", "us", 5},
+		{"alice", "eu", 7},
+		{"carol", "eu", 3},
+		{"bob", "us", 20},
+	}
+
+	t.Run("GroupingByWithSumming", func(t *testing.T) {
+		t.Parallel()
+		result := CollectTo(FromSlice(orders), GroupingByWith(
+			func(o order) string { return o.Customer },
+			MappingCollector(func(o order) int { return o.Amount }, SummingCollector[int]()),
+		))
+		assert.Equal(t, map[string]int{"alice": 17, "bob": 25, "carol": 3}, result, "GroupingByWith should sum per group")
+	})
+
+	t.Run("GroupingByWithCountingAndMax", func(t *testing.T) {
+		t.Parallel()
+		counts := CollectTo(FromSlice(orders), GroupingByWith(func(o order) string { return o.Region }, CountingCollector[order]()))
+		assert.Equal(t, map[string]int{"eu": 3, "us": 2}, counts, "GroupingByWith should count per group")
+
+		largest := CollectTo(FromSlice(orders), GroupingByWith(
+			func(o order) string { return o.Region },
+			MaxByCollector(func(a, b order) int { return a.Amount - b.Amount }),
+		))
+		assert.Equal(t, 10, largest["eu"].Get().Amount, "GroupingByWith should find max per group")
+		assert.Equal(t, 20, largest["us"].Get().Amount, "GroupingByWith should find max per group")
+	})
+
+	t.Run("NestedGrouping", func(t *testing.T) {
+		t.Parallel()
+		result := CollectTo(FromSlice(orders), GroupingByWith(
+			func(o order) string { return o.Region },
+			GroupingByWith(
+				func(o order) string { return o.Customer },
+				MappingCollector(func(o order) int { return o.Amount }, SummingCollector[int]()),
+			),
+		))
+		assert.Equal(t, map[string]map[string]int{
+			"eu": {"alice": 17, "carol": 3},
+			"us": {"bob": 25},
+		}, result, "Nested GroupingByWith should group at each level")
+	})
+
+	t.Run("GroupingByWithEmpty", func(t *testing.T) {
+		t.Parallel()
+		result := CollectTo(Empty[order](), GroupingByWith(func(o order) string { return o.Region }, CountingCollector[order]()))
+		assert.Empty(t, result, "GroupingByWith on empty stream should return empty map")
+	})
+
+	t.Run("PartitioningByWith", func(t *testing.T) {
+		t.Parallel()
+		result := CollectTo(FromSlice(orders), PartitioningByWith(
+			func(o order) bool { return o.Amount >= 10 },
+			CountingCollector[order](),
+		))
+		assert.Equal(t, map[bool]int{true: 2, false: 3}, result, "PartitioningByWith should count each partition")
+
+		empty := CollectTo(Empty[int](), PartitioningByWith(func(n int) bool { return n > 0 }, ToSliceCollector[int]()))
+		assert.Equal(t, map[bool][]int{true: {}, false: {}}, empty, "PartitioningByWith should always contain both keys")
+	})
+
+	t.Run("ParallelWithCombiner", func(t *testing.T) {
+		t.Parallel()
+		grouped := GroupingByWith(func(n int) int { return n % 3 }, ApproxDistinctCountCollector[int](0, nil))
+		require.NotNil(t, grouped.Combiner, "GroupingByWith should be mergeable when the downstream is")
+		result := ParallelCollectTo(Range(0, 3000), grouped, WithConcurrency(4))
+		assert.Equal(t, map[int]int64{0: 1000, 1: 1000, 2: 1000}, result, "Parallel grouping should merge per-key sketches")
+
+		parts := ParallelCollectTo(Range(0, 1000), PartitioningByWith(func(n int) bool { return n%2 == 0 }, TDigestCollector[int](100)), WithConcurrency(4))
+		assert.Equal(t, int64(500), parts[true].Count(), "Parallel partitioning should merge each partition")
+
+		assert.Nil(t, GroupingByWith(func(n int) int { return n }, ToSliceCollector[int]()).Combiner, "GroupingByWith should not be mergeable without a downstream Combiner")
+	})
+}
+
+func TestShortCircuitingCollectors(t *testing.T) {
+	t.Parallel()
+
+	naturals := func() Stream[int] {
+		n := 0
+		return Generate(func() int {
+			n++
+			return n
+		})
+	}
+
+	t.Run("FirstOnInfiniteStream", func(t *testing.T) {
+		t.Parallel()
+		first := CollectTo(naturals(), FirstCollector[int]())
+		assert.Equal(t, 1, first.Get(), "FirstCollector should stop after the first element")
+	})
+
+	t.Run("LimitingCollector", func(t *testing.T) {
+		t.Parallel()
+		result := CollectTo(naturals(), LimitingCollector(3, ToSliceCollector[int]()))
+		assert.Equal(t, []int{1, 2, 3}, result, "LimitingCollector should stop after n elements")
+
+		pulled := 0
+		counted := FromSlice([]int{1, 2, 3, 4, 5}).Peek(func(int) { pulled++ })
+		assert.Equal(t, 2, CollectTo(counted, LimitingCollector(2, CountingCollector[int]())))
+		assert.Equal(t, 2, pulled, "CollectTo should not pull past the done element")
+
+		assert.Empty(t, CollectTo(naturals(), LimitingCollector(0, ToSliceCollector[int]())), "Limit 0 should be done immediately")
+	})
+
+	t.Run("TakingWhileCollector", func(t *testing.T) {
+		t.Parallel()
+		sum := CollectTo(naturals(), TakingWhileCollector(func(n int) bool { return n <= 4 }, SummingCollector[int]()))
+		assert.Equal(t, 10, sum, "TakingWhileCollector should stop at the first failing element")
+	})
+
+	t.Run("AnyAllMatch", func(t *testing.T) {
+		t.Parallel()
+		assert.True(t, CollectTo(naturals(), AnyMatchCollector(func(n int) bool { return n > 100 })))
+		assert.False(t, CollectTo(naturals(), AllMatchCollector(func(n int) bool { return n < 100 })))
+		assert.True(t, CollectTo(Empty[int](), AllMatchCollector(func(n int) bool { return false })), "AllMatch on empty stream should be true")
+		assert.False(t, CollectTo(FromSlice([]int{1, 2, 3}), AnyMatchCollector(func(n int) bool { return n > 3 })))
+	})
+
+	t.Run("Teeing", func(t *testing.T) {
+		t.Parallel()
+		result := CollectTo(naturals(), TeeingCollector(
+			LimitingCollector(2, ToSliceCollector[int]()),
+			AnyMatchCollector(func(n int) bool { return n == 5 }),
+			func(head []int, found bool) Pair[[]int, bool] { return NewPair(head, found) },
+		))
+		assert.Equal(t, []int{1, 2}, result.First, "Done sub-collector should receive no further elements")
+		assert.True(t, result.Second, "Teeing should run until both sub-collectors are done")
+
+		notDone := TeeingCollector(FirstCollector[int](), CountingCollector[int](), func(Optional[int], int) int { return 0 })
+		assert.Nil(t, notDone.Done, "Teeing should not short-circuit unless both sub-collectors can")
+	})
+
+	t.Run("MappingAndFlatMapping", func(t *testing.T) {
+		t.Parallel()
+		mapped := CollectTo(naturals(), MappingCollector(func(n int) int { return n * 10 }, FirstCollector[int]()))
+		assert.Equal(t, 10, mapped.Get(), "MappingCollector should propagate Done")
+
+		flat := CollectTo(naturals(), FlatMappingCollector(
+			func(n int) Stream[int] { return FromSlice([]int{n, n}) },
+			LimitingCollector(3, ToSliceCollector[int]()),
+		))
+		assert.Equal(t, []int{1, 1, 2}, flat, "FlatMappingCollector should stop mid sub-stream when done")
+	})
+
+	t.Run("Grouping", func(t *testing.T) {
+		t.Parallel()
+		firsts := CollectTo(FromSlice([]int{1, 2, 3, 4, 5, 6}), GroupingByWith(
+			func(n int) bool { return n%2 == 0 },
+			LimitingCollector(2, ToSliceCollector[int]()),
+		))
+		assert.Equal(t, map[bool][]int{false: {1, 3}, true: {2, 4}}, firsts, "Done groups should skip further elements")
+
+		parts := CollectTo(naturals(), PartitioningByWith(func(n int) bool { return n%2 == 0 }, FirstCollector[int]()))
+		assert.Equal(t, 2, parts[true].Get())
+		assert.Equal(t, 1, parts[false].Get(), "PartitioningByWith should be done once both partitions are done")
+	})
+
+	t.Run("Parallel", func(t *testing.T) {
+		t.Parallel()
+		result := ParallelCollectTo(Range(0, 1000), AnyMatchCollector(func(n int) bool { return n == 500 }), WithConcurrency(4))
+		assert.True(t, result, "ParallelCollectTo should merge short-circuited accumulators")
+	})
+}
+
+func TestMultiAggregateCollectors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Teeing3", func(t *testing.T) {
+		t.Parallel()
+		result := CollectTo(Of(3, 1, 4, 1, 5), Teeing3Collector(
+			CountingCollector[int](),
+			SummingCollector[int](),
+			MaxByCollector(func(a, b int) int { return a - b }),
+			func(n, sum int, m Optional[int]) Triple[int, int, int] { return NewTriple(n, sum, m.Get()) },
+		))
+		assert.Equal(t, NewTriple(5, 14, 5), result, "Teeing3Collector should run all collectors in one pass")
+	})
+
+	t.Run("Teeing4", func(t *testing.T) {
+		t.Parallel()
+		result := CollectTo(Of(3, 1, 4, 1, 5), Teeing4Collector(
+			CountingCollector[int](),
+			SummingCollector[int](),
+			MinByCollector(func(a, b int) int { return a - b }),
+			ToSetCollector[int](),
+			func(n, sum int, m Optional[int], set map[int]struct{}) Quad[int, int, int, int] {
+				return NewQuad(n, sum, m.Get(), len(set))
+			},
+		))
+		assert.Equal(t, NewQuad(5, 14, 1, 4), result, "Teeing4Collector should run all collectors in one pass")
+
+		first := CollectTo(Iterate(1, func(n int) int { return n + 1 }), Teeing4Collector(
+			FirstCollector[int](),
+			LimitingCollector(2, SummingCollector[int]()),
+			AnyMatchCollector(func(n int) bool { return n == 3 }),
+			TakingWhileCollector(func(n int) bool { return n < 5 }, CountingCollector[int]()),
+			func(f Optional[int], sum int, found bool, n int) []int { return []int{f.Get(), sum, n} },
+		))
+		assert.Equal(t, []int{1, 3, 4}, first, "Teeing4Collector should stop once all collectors are done")
+	})
+
+	type summary struct {
+		Count    int
+		Sum      float64
+		Min, Max Optional[float64]
+		P99      float64
+	}
+	cmpFloat := func(a, b float64) int { return cmp.Compare(a, b) }
+	newSummary := func() *CompositeCollector[float64, summary] {
+		b := NewCompositeCollector[float64, summary]()
+		AddField(b, CountingCollector[float64](), func(s *summary, n int) { s.Count = n })
+		AddField(b, SummingCollector[float64](), func(s *summary, v float64) { s.Sum = v })
+		AddField(b, MinByCollector(cmpFloat), func(s *summary, v Optional[float64]) { s.Min = v })
+		AddField(b, MaxByCollector(cmpFloat), func(s *summary, v Optional[float64]) { s.Max = v })
+		AddField(b, TDigestCollector[float64](100), func(s *summary, td *TDigest) { s.P99 = td.Quantile(0.99).GetOrZero() })
+		return b
+	}
+
+	t.Run("Builder", func(t *testing.T) {
+		t.Parallel()
+		values := MapTo(Range(1, 1001), func(n int) float64 { return float64(n) })
+		res := CollectTo(values, newSummary().Build())
+		assert.Equal(t, 1000, res.Count)
+		assert.Equal(t, 500500.0, res.Sum)
+		assert.Equal(t, 1.0, res.Min.Get())
+		assert.Equal(t, 1000.0, res.Max.Get())
+		assert.InDelta(t, 990, res.P99, 5, "Builder should fill every field in one pass")
+
+		empty := CollectTo(Empty[float64](), newSummary().Build())
+		assert.Zero(t, empty.Count)
+		assert.True(t, empty.Min.IsEmpty(), "Empty stream should leave optional fields empty")
+		assert.NotNil(t, newSummary().Build().Combiner, "Builder should be mergeable when every field is")
+		unmergeable := newSummary()
+		AddField(unmergeable, ToSliceCollector[float64](), func(*summary, []float64) {})
+		assert.Nil(t, unmergeable.Build().Combiner, "Builder should not be mergeable unless every field is")
+		assert.Nil(t, newSummary().Build().Done, "Builder should not short-circuit unless every field can")
+	})
+
+	t.Run("BuilderParallelAndShortCircuit", func(t *testing.T) {
+		t.Parallel()
+		type flags struct{ HasBig, AllPositive bool }
+		b := NewCompositeCollector[int, flags]()
+		AddField(b, AnyMatchCollector(func(n int) bool { return n > 900 }), func(f *flags, v bool) { f.HasBig = v })
+		AddField(b, AllMatchCollector(func(n int) bool { return n > 0 }), func(f *flags, v bool) { f.AllPositive = v })
+		c := b.Build()
+		require.NotNil(t, c.Combiner)
+		assert.Equal(t, flags{HasBig: true, AllPositive: false}, ParallelCollectTo(Range(0, 1000), c, WithConcurrency(4)))
+
+		pulled := 0
+		res := CollectTo(Range(0, 1000).Peek(func(int) { pulled++ }), c)
+		assert.Equal(t, flags{HasBig: true, AllPositive: false}, res)
+		assert.Equal(t, 902, pulled, "Builder should stop once every field is done")
+	})
+}
+
+func TestCollectRunning(t *testing.T) {
+	t.Parallel()
+
+	t.Run("EveryElement", func(t *testing.T) {
+		t.Parallel()
+		result := CollectRunning(Of(3, 1, 4), SummingCollector[int](), 1).Collect()
+		assert.Equal(t, []int{3, 4, 8}, result, "CollectRunning should emit after each element")
+	})
+
+	t.Run("EveryN", func(t *testing.T) {
+		t.Parallel()
+		result := CollectRunning(Range(1, 8), CountingCollector[int](), 3).Collect()
+		assert.Equal(t, []int{3, 6, 7}, result, "CollectRunning should emit every n elements and the remainder")
+
+		exact := CollectRunning(Range(1, 7), CountingCollector[int](), 3).Collect()
+		assert.Equal(t, []int{3, 6}, exact, "CollectRunning should not repeat the last emission")
+	})
+
+	t.Run("EmptyAndInvalid", func(t *testing.T) {
+		t.Parallel()
+		assert.Empty(t, CollectRunning(Empty[int](), SummingCollector[int](), 1).Collect())
+		assert.Empty(t, CollectRunning(Of(1, 2), SummingCollector[int](), 0).Collect(), "every <= 0 should return empty stream")
+	})
+
+	t.Run("InfiniteAndDone", func(t *testing.T) {
+		t.Parallel()
+		naturals := Iterate(1, func(n int) int { return n + 1 })
+		maxes := CollectRunning(naturals, MaxByCollector(func(a, b int) int { return a - b }), 10).Limit(3).Collect()
+		assert.Equal(t, []int{10, 20, 30}, MapTo(FromSlice(maxes), Optional[int].Get).Collect(), "CollectRunning should be lazy")
+
+		limited := CollectRunning(naturals, LimitingCollector(5, SummingCollector[int]()), 2).Collect()
+		assert.Equal(t, []int{3, 10, 15}, limited, "CollectRunning should emit and stop once the collector is done")
+	})
+
+	t.Run("ByKey", func(t *testing.T) {
+		t.Parallel()
+		events := PairsOf(NewPair("a", 1), NewPair("b", 10), NewPair("a", 2), NewPair("b", 5), NewPair("a", 3))
+		changes := CollectRunningByKey(events, SummingCollector[int]()).CollectPairs()
+		assert.Equal(t, []Pair[string, int]{
+			{"a", 1}, {"b", 10}, {"a", 3}, {"b", 15}, {"a", 6},
+		}, changes, "CollectRunningByKey should emit each key's updated aggregate")
+
+		firsts := CollectRunningByKey(events, FirstCollector[int]()).Keys().Collect()
+		assert.Equal(t, []string{"a", "b"}, firsts, "Done keys should not emit again")
+	})
+}
@@ -1,5 +1,14 @@
 package streams
 
+import (
+	"context"
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
 // --- Join Operations for Stream2 ---
 
 // JoinResult holds the result of a join operation.
@@ -16,21 +25,160 @@ type JoinResultOptional[K, V1, V2 any] struct {
 	Right Optional[V2]
 }
 
+// --- Join Statistics ---
+
+// JoinStats reports statistics about a hash join once it finishes.
+// The build side is the stream collected into the lookup map (the right stream,
+// except for RightJoin and RightJoinWith where it is the left stream).
+type JoinStats[K any] struct {
+	BuildSize      int            // Number of elements on the build side
+	DistinctKeys   int            // Number of distinct keys on the build side
+	MaxGroupSize   int            // Size of the largest build-side group
+	HotKeys        []Pair[K, int] // Largest build-side groups by size, largest first
+	UnmatchedLeft  int            // Left elements without a matching right key
+	UnmatchedRight int            // Right elements without a matching left key
+	Elapsed        time.Duration  // Time from start of iteration until the join finished
+}
+
+// JoinConfig holds optional configuration for hash joins.
+type JoinConfig[K comparable] struct {
+	OnStats func(JoinStats[K]) // Called once when the join finishes (nil = disabled)
+	HotKeys int                // Number of hottest keys to report
+}
+
+// JoinOption is a function that modifies JoinConfig.
+// It is accepted by the hash joins in this file and by CoGroup.
+type JoinOption[K comparable] func(*JoinConfig[K])
+
+// WithJoinStats registers a hook that receives JoinStats once the join finishes,
+// reporting up to topN hottest build-side keys.
+// The hook is also called if the consumer stops early; counts then cover only the
+// elements processed so far.
+func WithJoinStats[K comparable](topN int, fn func(JoinStats[K])) JoinOption[K] {
+	return func(c *JoinConfig[K]) {
+		c.OnStats = fn
+		c.HotKeys = max(topN, 0)
+	}
+}
+
+// joinTracker accumulates join statistics. A nil tracker records nothing.
+type joinTracker[K comparable] struct {
+	cfg            JoinConfig[K]
+	start          time.Time
+	matched        map[K]struct{}
+	unmatchedProbe int
+	built          map[K]int // build-side group sizes, for joins that keep only a key set
+}
+
+// newJoinTracker returns a tracker for the given options, or nil if no stats hook is set.
+func newJoinTracker[K comparable](opts []JoinOption[K]) *joinTracker[K] {
+	var cfg JoinConfig[K]
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.OnStats == nil {
+		return nil
+	}
+	return &joinTracker[K]{
+		cfg:     cfg,
+		start:   time.Now(),
+		matched: make(map[K]struct{}),
+	}
+}
+
+// probe records n probe-side elements with key k and whether they matched the build side.
+func (jt *joinTracker[K]) probe(k K, n int, matched bool) {
+	if jt == nil {
+		return
+	}
+	if matched {
+		jt.matched[k] = struct{}{}
+	} else {
+		jt.unmatchedProbe += n
+	}
+}
+
+// build records a build-side element with key k, for joins that keep only a key set.
+func (jt *joinTracker[K]) build(k K) {
+	if jt == nil {
+		return
+	}
+	if jt.built == nil {
+		jt.built = make(map[K]int)
+	}
+	jt.built[k]++
+}
+
+// reportBuilt reports the statistics using the build-side keys recorded by build.
+func (jt *joinTracker[K]) reportBuilt(buildLeft bool) {
+	if jt == nil {
+		return
+	}
+	jt.report(maps.All(jt.built), buildLeft)
+}
+
+// report computes the final statistics from the build-side group sizes and calls the hook.
+func (jt *joinTracker[K]) report(groups iter.Seq2[K, int], buildLeft bool) {
+	if jt == nil {
+		return
+	}
+	stats := JoinStats[K]{Elapsed: time.Since(jt.start)}
+
+	top := TopKCollector(jt.cfg.HotKeys, func(a, b Pair[K, int]) bool { return a.Second < b.Second })
+	acc := top.Supplier()
+	unmatchedBuild := 0
+	for k, n := range groups {
+		stats.BuildSize += n
+		stats.DistinctKeys++
+		stats.MaxGroupSize = max(stats.MaxGroupSize, n)
+		if jt.cfg.HotKeys > 0 {
+			acc = top.Accumulator(acc, NewPair(k, n))
+		}
+		if _, ok := jt.matched[k]; !ok {
+			unmatchedBuild += n
+		}
+	}
+	stats.HotKeys = top.Finisher(acc)
+
+	if buildLeft {
+		stats.UnmatchedLeft, stats.UnmatchedRight = unmatchedBuild, jt.unmatchedProbe
+	} else {
+		stats.UnmatchedLeft, stats.UnmatchedRight = jt.unmatchedProbe, unmatchedBuild
+	}
+	jt.cfg.OnStats(stats)
+}
+
+// groupSizes returns the size of each group in a lookup map.
+func groupSizes[K comparable, V any](m map[K][]V) iter.Seq2[K, int] {
+	return func(yield func(K, int) bool) {
+		for k, vs := range m {
+			if !yield(k, len(vs)) {
+				return
+			}
+		}
+	}
+}
+
 // InnerJoin performs an inner join between two Stream2s on their keys.
 // Only pairs with matching keys in both streams are included.
 // Note: The second stream is collected into memory for the join.
-func InnerJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]) Stream[JoinResult[K, V1, V2]] {
+func InnerJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2], opts ...JoinOption[K]) Stream[JoinResult[K, V1, V2]] {
 	return Stream[JoinResult[K, V1, V2]]{
 		seq: func(yield func(JoinResult[K, V1, V2]) bool) {
+			stats := newJoinTracker(opts)
+
 			// Build lookup map from s2
 			lookup := make(map[K][]V2)
 			for k, v := range s2.seq {
 				lookup[k] = append(lookup[k], v)
 			}
+			defer stats.report(groupSizes(lookup), false)
 
 			// Join with s1
 			for k, v1 := range s1.seq {
-				if v2s, ok := lookup[k]; ok {
+				v2s, ok := lookup[k]
+				stats.probe(k, 1, ok)
+				if ok {
 					for _, v2 := range v2s {
 						if !yield(JoinResult[K, V1, V2]{Key: k, Left: v1, Right: v2}) {
 							return
@@ -45,18 +193,23 @@ func InnerJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]) S
 // LeftJoin performs a left outer join between two Stream2s.
 // All pairs from the left stream are included; right values are None if no match.
 // Note: The second stream is collected into memory for the join.
-func LeftJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]) Stream[JoinResultOptional[K, V1, V2]] {
+func LeftJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2], opts ...JoinOption[K]) Stream[JoinResultOptional[K, V1, V2]] {
 	return Stream[JoinResultOptional[K, V1, V2]]{
 		seq: func(yield func(JoinResultOptional[K, V1, V2]) bool) {
+			stats := newJoinTracker(opts)
+
 			// Build lookup map from s2
 			lookup := make(map[K][]V2)
 			for k, v := range s2.seq {
 				lookup[k] = append(lookup[k], v)
 			}
+			defer stats.report(groupSizes(lookup), false)
 
 			// Left join with s1
 			for k, v1 := range s1.seq {
-				if v2s, ok := lookup[k]; ok {
+				v2s, ok := lookup[k]
+				stats.probe(k, 1, ok)
+				if ok {
 					for _, v2 := range v2s {
 						if !yield(JoinResultOptional[K, V1, V2]{
 							Key:   k,
@@ -83,18 +236,23 @@ func LeftJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]) St
 // RightJoin performs a right outer join between two Stream2s.
 // All pairs from the right stream are included; left values are None if no match.
 // Note: Both streams are collected into memory for the join.
-func RightJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]) Stream[JoinResultOptional[K, V1, V2]] {
+func RightJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2], opts ...JoinOption[K]) Stream[JoinResultOptional[K, V1, V2]] {
 	return Stream[JoinResultOptional[K, V1, V2]]{
 		seq: func(yield func(JoinResultOptional[K, V1, V2]) bool) {
+			stats := newJoinTracker(opts)
+
 			// Build lookup map from s1
 			lookup := make(map[K][]V1)
 			for k, v := range s1.seq {
 				lookup[k] = append(lookup[k], v)
 			}
+			defer stats.report(groupSizes(lookup), true)
 
 			// Right join with s2
 			for k, v2 := range s2.seq {
-				if v1s, ok := lookup[k]; ok {
+				v1s, ok := lookup[k]
+				stats.probe(k, 1, ok)
+				if ok {
 					for _, v1 := range v1s {
 						if !yield(JoinResultOptional[K, V1, V2]{
 							Key:   k,
@@ -121,9 +279,11 @@ func RightJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]) S
 // FullJoin performs a full outer join between two Stream2s.
 // All pairs from both streams are included; missing values are None.
 // Note: Both streams are collected into memory for the join.
-func FullJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]) Stream[JoinResultOptional[K, V1, V2]] {
+func FullJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2], opts ...JoinOption[K]) Stream[JoinResultOptional[K, V1, V2]] {
 	return Stream[JoinResultOptional[K, V1, V2]]{
 		seq: func(yield func(JoinResultOptional[K, V1, V2]) bool) {
+			stats := newJoinTracker(opts)
+
 			// Collect both streams
 			left := make(map[K][]V1)
 			for k, v := range s1.seq {
@@ -134,13 +294,16 @@ func FullJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]) St
 			for k, v := range s2.seq {
 				right[k] = append(right[k], v)
 			}
+			defer stats.report(groupSizes(right), false)
 
 			// Track which right keys have been matched
 			matchedRight := make(map[K]bool)
 
 			// Iterate left, matching with right
 			for k, v1s := range left {
-				if v2s, ok := right[k]; ok {
+				v2s, ok := right[k]
+				stats.probe(k, len(v1s), ok)
+				if ok {
 					matchedRight[k] = true
 					for _, v1 := range v1s {
 						for _, v2 := range v2s {
@@ -187,16 +350,21 @@ func FullJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]) St
 // --- Simplified Join with Default Values ---
 
 // LeftJoinWith performs a left join with a default value for missing right values.
-func LeftJoinWith[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2], defaultV2 V2) Stream[JoinResult[K, V1, V2]] {
+func LeftJoinWith[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2], defaultV2 V2, opts ...JoinOption[K]) Stream[JoinResult[K, V1, V2]] {
 	return Stream[JoinResult[K, V1, V2]]{
 		seq: func(yield func(JoinResult[K, V1, V2]) bool) {
+			stats := newJoinTracker(opts)
+
 			lookup := make(map[K][]V2)
 			for k, v := range s2.seq {
 				lookup[k] = append(lookup[k], v)
 			}
+			defer stats.report(groupSizes(lookup), false)
 
 			for k, v1 := range s1.seq {
-				if v2s, ok := lookup[k]; ok {
+				v2s, ok := lookup[k]
+				stats.probe(k, 1, ok)
+				if ok {
 					for _, v2 := range v2s {
 						if !yield(JoinResult[K, V1, V2]{Key: k, Left: v1, Right: v2}) {
 							return
@@ -213,16 +381,21 @@ func LeftJoinWith[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]
 }
 
 // RightJoinWith performs a right join with a default value for missing left values.
-func RightJoinWith[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2], defaultV1 V1) Stream[JoinResult[K, V1, V2]] {
+func RightJoinWith[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2], defaultV1 V1, opts ...JoinOption[K]) Stream[JoinResult[K, V1, V2]] {
 	return Stream[JoinResult[K, V1, V2]]{
 		seq: func(yield func(JoinResult[K, V1, V2]) bool) {
+			stats := newJoinTracker(opts)
+
 			lookup := make(map[K][]V1)
 			for k, v := range s1.seq {
 				lookup[k] = append(lookup[k], v)
 			}
+			defer stats.report(groupSizes(lookup), true)
 
 			for k, v2 := range s2.seq {
-				if v1s, ok := lookup[k]; ok {
+				v1s, ok := lookup[k]
+				stats.probe(k, 1, ok)
+				if ok {
 					for _, v1 := range v1s {
 						if !yield(JoinResult[K, V1, V2]{Key: k, Left: v1, Right: v2}) {
 							return
@@ -249,9 +422,11 @@ type CoGrouped[K, V1, V2 any] struct {
 
 // CoGroup groups values from two streams by their keys.
 // Similar to SQL's FULL OUTER JOIN but groups all matching values together.
-func CoGroup[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]) Stream[CoGrouped[K, V1, V2]] {
+func CoGroup[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2], opts ...JoinOption[K]) Stream[CoGrouped[K, V1, V2]] {
 	return Stream[CoGrouped[K, V1, V2]]{
 		seq: func(yield func(CoGrouped[K, V1, V2]) bool) {
+			stats := newJoinTracker(opts)
+
 			// Collect both streams
 			left := make(map[K][]V1)
 			for k, v := range s1.seq {
@@ -262,11 +437,14 @@ func CoGroup[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]) Str
 			for k, v := range s2.seq {
 				right[k] = append(right[k], v)
 			}
+			defer stats.report(groupSizes(right), false)
 
 			// Get all unique keys
 			allKeys := make(map[K]struct{})
-			for k := range left {
+			for k, v1s := range left {
 				allKeys[k] = struct{}{}
+				_, ok := right[k]
+				stats.probe(k, len(v1s), ok)
 			}
 			for k := range right {
 				allKeys[k] = struct{}{}
@@ -289,20 +467,25 @@ func CoGroup[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]) Str
 // --- Stream-based Join (for Stream[T]) ---
 
 // JoinBy performs an inner join on two streams using key extraction functions.
-func JoinBy[T, U, K comparable](s1 Stream[T], s2 Stream[U], keyT func(T) K, keyU func(U) K) Stream[Pair[T, U]] {
+func JoinBy[T, U, K comparable](s1 Stream[T], s2 Stream[U], keyT func(T) K, keyU func(U) K, opts ...JoinOption[K]) Stream[Pair[T, U]] {
 	return Stream[Pair[T, U]]{
 		seq: func(yield func(Pair[T, U]) bool) {
+			stats := newJoinTracker(opts)
+
 			// Build lookup from s2
 			lookup := make(map[K][]U)
 			for u := range s2.seq {
 				k := keyU(u)
 				lookup[k] = append(lookup[k], u)
 			}
+			defer stats.report(groupSizes(lookup), false)
 
 			// Join with s1
 			for t := range s1.seq {
 				k := keyT(t)
-				if us, ok := lookup[k]; ok {
+				us, ok := lookup[k]
+				stats.probe(k, 1, ok)
+				if ok {
 					for _, u := range us {
 						if !yield(Pair[T, U]{First: t, Second: u}) {
 							return
@@ -315,18 +498,23 @@ func JoinBy[T, U, K comparable](s1 Stream[T], s2 Stream[U], keyT func(T) K, keyU
 }
 
 // LeftJoinBy performs a left join on two streams using key extraction functions.
-func LeftJoinBy[T, U any, K comparable](s1 Stream[T], s2 Stream[U], keyT func(T) K, keyU func(U) K) Stream[Pair[T, Optional[U]]] {
+func LeftJoinBy[T, U any, K comparable](s1 Stream[T], s2 Stream[U], keyT func(T) K, keyU func(U) K, opts ...JoinOption[K]) Stream[Pair[T, Optional[U]]] {
 	return Stream[Pair[T, Optional[U]]]{
 		seq: func(yield func(Pair[T, Optional[U]]) bool) {
+			stats := newJoinTracker(opts)
+
 			lookup := make(map[K][]U)
 			for u := range s2.seq {
 				k := keyU(u)
 				lookup[k] = append(lookup[k], u)
 			}
+			defer stats.report(groupSizes(lookup), false)
 
 			for t := range s1.seq {
 				k := keyT(t)
-				if us, ok := lookup[k]; ok {
+				us, ok := lookup[k]
+				stats.probe(k, 1, ok)
+				if ok {
 					for _, u := range us {
 						if !yield(Pair[T, Optional[U]]{First: t, Second: Some(u)}) {
 							return
@@ -346,18 +534,24 @@ func LeftJoinBy[T, U any, K comparable](s1 Stream[T], s2 Stream[U], keyT func(T)
 
 // SemiJoin returns elements from s1 that have matching keys in s2.
 // Unlike inner join, it doesn't include the matching elements from s2.
-func SemiJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]) Stream2[K, V1] {
+func SemiJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2], opts ...JoinOption[K]) Stream2[K, V1] {
 	return Stream2[K, V1]{
 		seq: func(yield func(K, V1) bool) {
+			stats := newJoinTracker(opts)
+
 			// Build set of keys from s2
 			keys := make(map[K]struct{})
 			for k := range s2.seq {
 				keys[k] = struct{}{}
+				stats.build(k)
 			}
+			defer stats.reportBuilt(false)
 
 			// Filter s1 to only matching keys
 			for k, v := range s1.seq {
-				if _, ok := keys[k]; ok {
+				_, ok := keys[k]
+				stats.probe(k, 1, ok)
+				if ok {
 					if !yield(k, v) {
 						return
 					}
@@ -368,18 +562,24 @@ func SemiJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]) St
 }
 
 // AntiJoin returns elements from s1 that don't have matching keys in s2.
-func AntiJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]) Stream2[K, V1] {
+func AntiJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2], opts ...JoinOption[K]) Stream2[K, V1] {
 	return Stream2[K, V1]{
 		seq: func(yield func(K, V1) bool) {
+			stats := newJoinTracker(opts)
+
 			// Build set of keys from s2
 			keys := make(map[K]struct{})
 			for k := range s2.seq {
 				keys[k] = struct{}{}
+				stats.build(k)
 			}
+			defer stats.reportBuilt(false)
 
 			// Filter s1 to only non-matching keys
 			for k, v := range s1.seq {
-				if _, ok := keys[k]; !ok {
+				_, ok := keys[k]
+				stats.probe(k, 1, ok)
+				if !ok {
 					if !yield(k, v) {
 						return
 					}
@@ -390,16 +590,24 @@ func AntiJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2]) St
 }
 
 // SemiJoinBy returns elements from s1 that have matching keys in s2 (using key extractors).
-func SemiJoinBy[T, U any, K comparable](s1 Stream[T], s2 Stream[U], keyT func(T) K, keyU func(U) K) Stream[T] {
+func SemiJoinBy[T, U any, K comparable](s1 Stream[T], s2 Stream[U], keyT func(T) K, keyU func(U) K, opts ...JoinOption[K]) Stream[T] {
 	return Stream[T]{
 		seq: func(yield func(T) bool) {
+			stats := newJoinTracker(opts)
+
 			keys := make(map[K]struct{})
 			for u := range s2.seq {
-				keys[keyU(u)] = struct{}{}
+				k := keyU(u)
+				keys[k] = struct{}{}
+				stats.build(k)
 			}
+			defer stats.reportBuilt(false)
 
 			for t := range s1.seq {
-				if _, ok := keys[keyT(t)]; ok {
+				k := keyT(t)
+				_, ok := keys[k]
+				stats.probe(k, 1, ok)
+				if ok {
 					if !yield(t) {
 						return
 					}
@@ -410,16 +618,24 @@ func SemiJoinBy[T, U any, K comparable](s1 Stream[T], s2 Stream[U], keyT func(T)
 }
 
 // AntiJoinBy returns elements from s1 that don't have matching keys in s2 (using key extractors).
-func AntiJoinBy[T, U any, K comparable](s1 Stream[T], s2 Stream[U], keyT func(T) K, keyU func(U) K) Stream[T] {
+func AntiJoinBy[T, U any, K comparable](s1 Stream[T], s2 Stream[U], keyT func(T) K, keyU func(U) K, opts ...JoinOption[K]) Stream[T] {
 	return Stream[T]{
 		seq: func(yield func(T) bool) {
+			stats := newJoinTracker(opts)
+
 			keys := make(map[K]struct{})
 			for u := range s2.seq {
-				keys[keyU(u)] = struct{}{}
+				k := keyU(u)
+				keys[k] = struct{}{}
+				stats.build(k)
 			}
+			defer stats.reportBuilt(false)
 
 			for t := range s1.seq {
-				if _, ok := keys[keyT(t)]; !ok {
+				k := keyT(t)
+				_, ok := keys[k]
+				stats.probe(k, 1, ok)
+				if !ok {
 					if !yield(t) {
 						return
 					}
@@ -428,3 +644,498 @@ func AntiJoinBy[T, U any, K comparable](s1 Stream[T], s2 Stream[U], keyT func(T)
 		},
 	}
 }
+
+// --- As-Of Join ---
+
+// AsOfJoin performs an as-of (nearest-preceding) join between two time-ordered streams.
+// For each left element, it pairs the most recent right element with the same key
+// whose timestamp is at or before the left element's timestamp.
+// If maxStaleness > 0, right elements older than maxStaleness relative to the left
+// timestamp are treated as missing; maxStaleness <= 0 disables the limit.
+// All left elements are included; the right value is None if no match exists.
+// Both streams must be ordered by timestamp. Only the latest right element per key is
+// kept in memory, so the right stream is consumed incrementally alongside the left.
+func AsOfJoin[T, U any, K comparable](
+	left Stream[T],
+	right Stream[U],
+	keyT func(T) K,
+	keyU func(U) K,
+	timeT func(T) time.Time,
+	timeU func(U) time.Time,
+	maxStaleness time.Duration,
+) Stream[JoinResultOptional[K, T, U]] {
+	return Stream[JoinResultOptional[K, T, U]]{
+		seq: func(yield func(JoinResultOptional[K, T, U]) bool) {
+			next, stop := iter.Pull(right.seq)
+			defer stop()
+
+			latest := make(map[K]U)
+			var (
+				pending    U
+				hasPending bool
+				exhausted  bool
+			)
+
+			for t := range left.seq {
+				ts := timeT(t)
+
+				// Advance the right side up to the left timestamp
+				for !exhausted {
+					if !hasPending {
+						pending, hasPending = next()
+						if !hasPending {
+							exhausted = true
+							break
+						}
+					}
+					if timeU(pending).After(ts) {
+						break
+					}
+					latest[keyU(pending)] = pending
+					hasPending = false
+				}
+
+				k := keyT(t)
+				match := None[U]()
+				if u, ok := latest[k]; ok {
+					if maxStaleness <= 0 || ts.Sub(timeU(u)) <= maxStaleness {
+						match = Some(u)
+					}
+				}
+
+				if !yield(JoinResultOptional[K, T, U]{Key: k, Left: Some(t), Right: match}) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// --- Windowed Stream-Stream Join ---
+
+// WindowJoinConfig holds configuration for windowed stream-stream joins.
+type WindowJoinConfig struct {
+	Window     time.Duration // How long elements are retained for matching (0 = no time limit)
+	MaxPerKey  int           // Max elements retained per key on each side (0 = no count limit)
+	BufferSize int           // Size of the input buffer shared by both sides
+}
+
+// DefaultWindowJoinConfig returns the default windowed join configuration.
+func DefaultWindowJoinConfig() WindowJoinConfig {
+	return WindowJoinConfig{
+		Window:     time.Minute,
+		MaxPerKey:  0, // No count limit by default
+		BufferSize: 64,
+	}
+}
+
+// WindowJoinOption is a function that modifies WindowJoinConfig.
+type WindowJoinOption func(*WindowJoinConfig)
+
+// WithJoinWindow sets how long elements are retained for matching.
+// Set to 0 to disable time-based eviction (combine with WithJoinMaxPerKey to bound memory).
+func WithJoinWindow(d time.Duration) WindowJoinOption {
+	return func(c *WindowJoinConfig) {
+		if d >= 0 {
+			c.Window = d
+		}
+	}
+}
+
+// WithJoinMaxPerKey sets the maximum number of elements retained per key on each side.
+// Older elements are evicted first. Set to 0 to disable count-based eviction.
+func WithJoinMaxPerKey(n int) WindowJoinOption {
+	return func(c *WindowJoinConfig) {
+		if n >= 0 {
+			c.MaxPerKey = n
+		}
+	}
+}
+
+// WithJoinBufferSize sets the size of the input buffer shared by both sides.
+func WithJoinBufferSize(size int) WindowJoinOption {
+	return func(c *WindowJoinConfig) {
+		if size > 0 {
+			c.BufferSize = size
+		}
+	}
+}
+
+// windowEntry holds a retained element with its arrival time.
+type windowEntry[V any] struct {
+	value V
+	at    time.Time
+}
+
+// windowJoinEvent holds an element arriving from either side of a windowed join.
+type windowJoinEvent[K, V1, V2 any] struct {
+	fromLeft bool
+	key      K
+	left     V1
+	right    V2
+}
+
+// evictWindow drops entries that fall outside the time or count window.
+// Entries are kept in arrival order, so expired ones are always at the front.
+func evictWindow[V any](entries []windowEntry[V], now time.Time, cfg WindowJoinConfig) []windowEntry[V] {
+	drop := 0
+	if cfg.Window > 0 {
+		cutoff := now.Add(-cfg.Window)
+		for drop < len(entries) && entries[drop].at.Before(cutoff) {
+			drop++
+		}
+	}
+	if cfg.MaxPerKey > 0 && len(entries)-drop > cfg.MaxPerKey {
+		drop = len(entries) - cfg.MaxPerKey
+	}
+	if drop == 0 {
+		return entries
+	}
+	return append(entries[:0], entries[drop:]...)
+}
+
+// WindowJoin performs a symmetric hash join between two Stream2s that are consumed concurrently.
+// Unlike InnerJoin, neither stream needs to terminate: each arriving element is matched
+// against the retained elements of the other side and results are emitted immediately.
+// Per-key state is retained only within the configured time and/or count window and
+// expired state is evicted as new elements arrive and periodically for idle keys.
+// Elements are timestamped on arrival (wall clock).
+// The join ends when both streams are exhausted or the context is cancelled.
+// Sources that block (e.g. channels) should honor ctx, for example via FromChannelCtx.
+// If the consumer stops early, the join returns without waiting for its two producer
+// goroutines: one blocked inside a source exits only once the source yields or ends,
+// so callers must cancel ctx after stopping early to release ctx-aware sources.
+func WindowJoin[K comparable, V1, V2 any](ctx context.Context, s1 Stream2[K, V1], s2 Stream2[K, V2], opts ...WindowJoinOption) Stream[JoinResult[K, V1, V2]] {
+	cfg := DefaultWindowJoinConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return Stream[JoinResult[K, V1, V2]]{
+		seq: func(yield func(JoinResult[K, V1, V2]) bool) {
+			var (
+				events = make(chan windowJoinEvent[K, V1, V2], cfg.BufferSize)
+				done   = make(chan struct{})
+				wg     sync.WaitGroup
+			)
+			defer close(done)
+
+			// Consume both sides concurrently
+			wg.Go(func() {
+				for k, v := range s1.seq {
+					select {
+					case <-ctx.Done():
+						return
+					case <-done:
+						return
+					case events <- windowJoinEvent[K, V1, V2]{fromLeft: true, key: k, left: v}:
+					}
+				}
+			})
+			wg.Go(func() {
+				for k, v := range s2.seq {
+					select {
+					case <-ctx.Done():
+						return
+					case <-done:
+						return
+					case events <- windowJoinEvent[K, V1, V2]{key: k, right: v}:
+					}
+				}
+			})
+			go func() { wg.Wait(); close(events) }()
+
+			// Periodically sweep idle keys when a time window is configured
+			var sweep <-chan time.Time
+			if cfg.Window > 0 {
+				ticker := time.NewTicker(cfg.Window)
+				defer ticker.Stop()
+				sweep = ticker.C
+			}
+
+			left := make(map[K][]windowEntry[V1])
+			right := make(map[K][]windowEntry[V2])
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+
+				case now := <-sweep:
+					for k, entries := range left {
+						if entries = evictWindow(entries, now, cfg); len(entries) == 0 {
+							delete(left, k)
+						} else {
+							left[k] = entries
+						}
+					}
+					for k, entries := range right {
+						if entries = evictWindow(entries, now, cfg); len(entries) == 0 {
+							delete(right, k)
+						} else {
+							right[k] = entries
+						}
+					}
+
+				case ev, ok := <-events:
+					if !ok {
+						return
+					}
+					now := time.Now()
+					if ev.fromLeft {
+						matches := evictWindow(right[ev.key], now, cfg)
+						if len(matches) == 0 {
+							delete(right, ev.key)
+						} else {
+							right[ev.key] = matches
+						}
+						left[ev.key] = evictWindow(append(left[ev.key], windowEntry[V1]{value: ev.left, at: now}), now, cfg)
+
+						for _, m := range matches {
+							if !yield(JoinResult[K, V1, V2]{Key: ev.key, Left: ev.left, Right: m.value}) {
+								return
+							}
+						}
+					} else {
+						matches := evictWindow(left[ev.key], now, cfg)
+						if len(matches) == 0 {
+							delete(left, ev.key)
+						} else {
+							left[ev.key] = matches
+						}
+						right[ev.key] = evictWindow(append(right[ev.key], windowEntry[V2]{value: ev.right, at: now}), now, cfg)
+
+						for _, m := range matches {
+							if !yield(JoinResult[K, V1, V2]{Key: ev.key, Left: m.value, Right: ev.right}) {
+								return
+							}
+						}
+					}
+				}
+			}
+		},
+	}
+}
+
+// WindowJoinBy performs a windowed symmetric hash join on two streams using key extraction functions.
+// See WindowJoin for windowing and termination semantics.
+func WindowJoinBy[T, U any, K comparable](ctx context.Context, s1 Stream[T], s2 Stream[U], keyT func(T) K, keyU func(U) K, opts ...WindowJoinOption) Stream[Pair[T, U]] {
+	keyed1 := Stream2[K, T]{
+		seq: func(yield func(K, T) bool) {
+			for t := range s1.seq {
+				if !yield(keyT(t), t) {
+					return
+				}
+			}
+		},
+	}
+	keyed2 := Stream2[K, U]{
+		seq: func(yield func(K, U) bool) {
+			for u := range s2.seq {
+				if !yield(keyU(u), u) {
+					return
+				}
+			}
+		},
+	}
+
+	return Stream[Pair[T, U]]{
+		seq: func(yield func(Pair[T, U]) bool) {
+			for r := range WindowJoin(ctx, keyed1, keyed2, opts...).seq {
+				if !yield(Pair[T, U]{First: r.Left, Second: r.Right}) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// --- Similarity Join ---
+
+// SimilarityMatch holds a pair of elements matched by a similarity join.
+type SimilarityMatch[T, U any] struct {
+	Left  T
+	Right U
+	Score float64 // Similarity in [0, 1], where 1 means identical keys
+}
+
+// ngramSet returns the distinct rune n-grams of s.
+// Strings shorter than n yield a single gram containing the whole string.
+func ngramSet(s string, n int) []string {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return nil
+	}
+	if len(runes) <= n {
+		return []string{s}
+	}
+	seen := make(map[string]struct{}, len(runes)-n+1)
+	grams := make([]string, 0, len(runes)-n+1)
+	for i := 0; i+n <= len(runes); i++ {
+		g := string(runes[i : i+n])
+		if _, ok := seen[g]; !ok {
+			seen[g] = struct{}{}
+			grams = append(grams, g)
+		}
+	}
+	return grams
+}
+
+// qgramCounts returns the multiset of rune q-grams of r.
+func qgramCounts(r []rune, q int) map[string]int {
+	counts := make(map[string]int)
+	for i := 0; i+q <= len(r); i++ {
+		counts[string(r[i:i+q])]++
+	}
+	return counts
+}
+
+// levenshtein returns the edit distance between two rune slices.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// SimilarityJoinJaccard matches elements from two streams whose string keys have a Jaccard
+// similarity of at least threshold, computed over their sets of rune n-grams.
+// Candidates are found through an n-gram index of the second stream, so only pairs sharing
+// at least one n-gram are compared. Keys are compared as-is; normalize case in the key
+// functions if needed. Matches for each left element are yielded in right-stream order.
+// Note: The second stream is collected into memory for the join.
+func SimilarityJoinJaccard[T, U any](s1 Stream[T], s2 Stream[U], keyT func(T) string, keyU func(U) string, n int, threshold float64) Stream[SimilarityMatch[T, U]] {
+	n = max(n, 1)
+	return Stream[SimilarityMatch[T, U]]{
+		seq: func(yield func(SimilarityMatch[T, U]) bool) {
+			// Build n-gram index from s2
+			var (
+				items []U
+				sizes []int
+				index = make(map[string][]int)
+			)
+			for u := range s2.seq {
+				grams := ngramSet(keyU(u), n)
+				for _, g := range grams {
+					index[g] = append(index[g], len(items))
+				}
+				items = append(items, u)
+				sizes = append(sizes, len(grams))
+			}
+
+			for t := range s1.seq {
+				grams := ngramSet(keyT(t), n)
+				overlap := make(map[int]int)
+				for _, g := range grams {
+					for _, idx := range index[g] {
+						overlap[idx]++
+					}
+				}
+
+				for _, idx := range slices.Sorted(maps.Keys(overlap)) {
+					shared := overlap[idx]
+					score := float64(shared) / float64(len(grams)+sizes[idx]-shared)
+					if score >= threshold {
+						if !yield(SimilarityMatch[T, U]{Left: t, Right: items[idx], Score: score}) {
+							return
+						}
+					}
+				}
+			}
+		},
+	}
+}
+
+// SimilarityJoinEditDistance matches elements from two streams whose string keys are within
+// maxDistance edits (Levenshtein distance over runes) of each other.
+// Candidates are found through a 2-gram index of the second stream using the q-gram count
+// filter, then verified exactly. The Score is 1 - distance/max(len(a), len(b)).
+// Matches for each left element are yielded in right-stream order.
+// Note: The second stream is collected into memory for the join.
+func SimilarityJoinEditDistance[T, U any](s1 Stream[T], s2 Stream[U], keyT func(T) string, keyU func(U) string, maxDistance int) Stream[SimilarityMatch[T, U]] {
+	const q = 2
+	maxDistance = max(maxDistance, 0)
+	// Keys up to this length may match with no shared q-grams, so they bypass the index.
+	shortLen := q*(maxDistance+1) - 1
+
+	type posting struct {
+		idx   int
+		count int
+	}
+
+	return Stream[SimilarityMatch[T, U]]{
+		seq: func(yield func(SimilarityMatch[T, U]) bool) {
+			// Build q-gram index from s2
+			var (
+				items []U
+				keys  [][]rune
+				short []int
+				index = make(map[string][]posting)
+			)
+			for u := range s2.seq {
+				r := []rune(keyU(u))
+				for g, c := range qgramCounts(r, q) {
+					index[g] = append(index[g], posting{idx: len(items), count: c})
+				}
+				if len(r) <= shortLen {
+					short = append(short, len(items))
+				}
+				items = append(items, u)
+				keys = append(keys, r)
+			}
+
+			for t := range s1.seq {
+				r := []rune(keyT(t))
+				overlap := make(map[int]int)
+				for g, c := range qgramCounts(r, q) {
+					for _, p := range index[g] {
+						overlap[p.idx] += min(c, p.count)
+					}
+				}
+				if len(r) <= shortLen {
+					for _, idx := range short {
+						if _, ok := overlap[idx]; !ok {
+							overlap[idx] = 0
+						}
+					}
+				}
+
+				for _, idx := range slices.Sorted(maps.Keys(overlap)) {
+					other := keys[idx]
+					longest := max(len(r), len(other))
+					if diff := len(r) - len(other); diff > maxDistance || diff < -maxDistance {
+						continue
+					}
+					// q-gram lemma: strings within d edits share at least this many q-grams
+					if overlap[idx] < longest-q+1-maxDistance*q {
+						continue
+					}
+					dist := levenshtein(r, other)
+					if dist > maxDistance {
+						continue
+					}
+					score := 1.0
+					if longest > 0 {
+						score = 1 - float64(dist)/float64(longest)
+					}
+					if !yield(SimilarityMatch[T, U]{Left: t, Right: items[idx], Score: score}) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
@@ -1,8 +1,10 @@
 package streams
 
 import (
+	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -967,3 +969,402 @@ func TestCoGroupedType(t *testing.T) {
 		assert.Equal(t, []string{"a", "b"}, grouped.Right, "CoGrouped.Right should match")
 	})
 }
+
+// --- AsOfJoin Tests ---
+
+func TestAsOfJoin(t *testing.T) {
+	t.Parallel()
+	type Trade struct {
+		Symbol string
+		At     time.Time
+	}
+	type Quote struct {
+		Symbol string
+		At     time.Time
+		Price  float64
+	}
+
+	base := time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC)
+	at := func(sec int) time.Time { return base.Add(time.Duration(sec) * time.Second) }
+	tradeKey := func(tr Trade) string { return tr.Symbol }
+	quoteKey := func(q Quote) string { return q.Symbol }
+	tradeTime := func(tr Trade) time.Time { return tr.At }
+	quoteTime := func(q Quote) time.Time { return q.At }
+
+	t.Run("NearestPreceding", func(t *testing.T) {
+		t.Parallel()
+		trades := Of(
+			Trade{"AAPL", at(5)},
+			Trade{"MSFT", at(6)},
+			Trade{"AAPL", at(10)},
+		)
+		quotes := Of(
+			Quote{"AAPL", at(1), 100},
+			Quote{"MSFT", at(2), 200},
+			Quote{"AAPL", at(4), 101},
+			Quote{"AAPL", at(10), 102},
+			Quote{"AAPL", at(11), 103},
+		)
+
+		result := AsOfJoin(trades, quotes, tradeKey, quoteKey, tradeTime, quoteTime, 0).Collect()
+
+		assert.Len(t, result, 3, "AsOfJoin should emit one row per left element")
+		assert.Equal(t, 101.0, result[0].Right.Get().Price, "AsOfJoin should pick latest quote before trade")
+		assert.Equal(t, 200.0, result[1].Right.Get().Price, "AsOfJoin should match by key")
+		assert.Equal(t, 102.0, result[2].Right.Get().Price, "AsOfJoin should include quote at the same timestamp")
+		assert.Equal(t, "AAPL", result[2].Key, "AsOfJoin should set the key")
+		assert.True(t, result[2].Left.IsPresent(), "AsOfJoin Left should always be present")
+	})
+
+	t.Run("NoPrecedingMatch", func(t *testing.T) {
+		t.Parallel()
+		trades := Of(Trade{"AAPL", at(1)}, Trade{"GOOG", at(5)})
+		quotes := Of(Quote{"AAPL", at(2), 100})
+
+		result := AsOfJoin(trades, quotes, tradeKey, quoteKey, tradeTime, quoteTime, 0).Collect()
+
+		assert.Len(t, result, 2, "AsOfJoin should keep unmatched left elements")
+		assert.False(t, result[0].Right.IsPresent(), "AsOfJoin should not match a later right element")
+		assert.False(t, result[1].Right.IsPresent(), "AsOfJoin should not match a missing key")
+	})
+
+	t.Run("MaxStaleness", func(t *testing.T) {
+		t.Parallel()
+		trades := Of(Trade{"AAPL", at(3)}, Trade{"AAPL", at(20)})
+		quotes := Of(Quote{"AAPL", at(1), 100})
+
+		result := AsOfJoin(trades, quotes, tradeKey, quoteKey, tradeTime, quoteTime, 5*time.Second).Collect()
+
+		assert.True(t, result[0].Right.IsPresent(), "AsOfJoin should match within staleness")
+		assert.False(t, result[1].Right.IsPresent(), "AsOfJoin should drop stale matches")
+	})
+
+	t.Run("EmptyRightStream", func(t *testing.T) {
+		t.Parallel()
+		trades := Of(Trade{"AAPL", at(1)})
+
+		result := AsOfJoin(trades, Empty[Quote](), tradeKey, quoteKey, tradeTime, quoteTime, 0).Collect()
+
+		assert.Len(t, result, 1, "AsOfJoin with empty right should keep left")
+		assert.False(t, result[0].Right.IsPresent(), "AsOfJoin with empty right should yield None")
+	})
+
+	t.Run("EarlyTermination", func(t *testing.T) {
+		t.Parallel()
+		trades := Of(Trade{"AAPL", at(1)}, Trade{"AAPL", at(2)}, Trade{"AAPL", at(3)})
+		quotes := Of(Quote{"AAPL", at(0), 100})
+
+		result := AsOfJoin(trades, quotes, tradeKey, quoteKey, tradeTime, quoteTime, 0).Limit(1).Collect()
+		assert.Len(t, result, 1, "AsOfJoin should respect Limit")
+	})
+}
+
+// --- WindowJoin Tests ---
+
+func TestWindowJoinConfig(t *testing.T) {
+	t.Parallel()
+	cfg := DefaultWindowJoinConfig()
+	assert.Equal(t, time.Minute, cfg.Window, "Default Window should be one minute")
+	assert.Equal(t, 0, cfg.MaxPerKey, "Default MaxPerKey should be unlimited")
+
+	WithJoinWindow(time.Second)(&cfg)
+	WithJoinMaxPerKey(3)(&cfg)
+	WithJoinBufferSize(8)(&cfg)
+	assert.Equal(t, time.Second, cfg.Window, "WithJoinWindow should set Window")
+	assert.Equal(t, 3, cfg.MaxPerKey, "WithJoinMaxPerKey should set MaxPerKey")
+	assert.Equal(t, 8, cfg.BufferSize, "WithJoinBufferSize should set BufferSize")
+
+	WithJoinWindow(-1)(&cfg)
+	WithJoinMaxPerKey(-1)(&cfg)
+	WithJoinBufferSize(0)(&cfg)
+	assert.Equal(t, time.Second, cfg.Window, "WithJoinWindow should ignore negative values")
+	assert.Equal(t, 3, cfg.MaxPerKey, "WithJoinMaxPerKey should ignore negative values")
+	assert.Equal(t, 8, cfg.BufferSize, "WithJoinBufferSize should ignore non-positive values")
+}
+
+func TestWindowJoin(t *testing.T) {
+	t.Parallel()
+	t.Run("FiniteStreamsMatchInnerJoin", func(t *testing.T) {
+		t.Parallel()
+		s1 := PairsOf(NewPair("a", 1), NewPair("b", 2), NewPair("a", 3))
+		s2 := PairsOf(NewPair("a", "x"), NewPair("c", "y"), NewPair("b", "z"))
+
+		result := WindowJoin(context.Background(), s1, s2).Collect()
+
+		got := make([]string, len(result))
+		for i, r := range result {
+			got[i] = r.Key + r.Right + string(rune('0'+r.Left))
+		}
+		sort.Strings(got)
+		assert.Equal(t, []string{"ax1", "ax3", "bz2"}, got, "WindowJoin should emit every matching pair once")
+	})
+
+	t.Run("CountWindowEvictsOldest", func(t *testing.T) {
+		t.Parallel()
+		rightDone := make(chan struct{})
+		s2 := Stream2[string, int]{
+			seq: func(yield func(string, int) bool) {
+				defer close(rightDone)
+				for i := 1; i <= 3; i++ {
+					if !yield("k", i) {
+						return
+					}
+				}
+			},
+		}
+		// Left waits until every right element has been queued
+		s1 := Stream2[string, string]{
+			seq: func(yield func(string, string) bool) {
+				<-rightDone
+				yield("k", "L")
+			},
+		}
+
+		result := WindowJoin(context.Background(), s1, s2, WithJoinMaxPerKey(2)).Collect()
+
+		rights := make([]int, len(result))
+		for i, r := range result {
+			rights[i] = r.Right
+		}
+		assert.Equal(t, []int{2, 3}, rights, "WindowJoin should only retain the last 2 right elements")
+	})
+
+	t.Run("TimeWindowEvictsExpired", func(t *testing.T) {
+		t.Parallel()
+		leftCh := make(chan int)
+		rightCh := make(chan int)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			rightCh <- 1 // expires before the left side arrives
+			time.Sleep(100 * time.Millisecond)
+			rightCh <- 2
+			time.Sleep(10 * time.Millisecond)
+			leftCh <- 1
+			leftCh <- 2
+			close(leftCh)
+			close(rightCh)
+		}()
+
+		result := WindowJoinBy(ctx, FromChannelCtx(ctx, leftCh), FromChannelCtx(ctx, rightCh),
+			func(v int) int { return 0 }, func(v int) int { return 0 },
+			WithJoinWindow(50*time.Millisecond)).Collect()
+
+		for _, p := range result {
+			assert.Equal(t, 2, p.Second, "WindowJoinBy should not match expired right elements")
+		}
+		assert.Len(t, result, 2, "WindowJoinBy should match both left elements with the live right element")
+	})
+
+	t.Run("ContextCancellation", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		leftCh := make(chan int)
+		rightCh := make(chan int)
+
+		done := make(chan []Pair[int, int])
+		go func() {
+			done <- WindowJoinBy(ctx, FromChannelCtx(ctx, leftCh), FromChannelCtx(ctx, rightCh),
+				func(v int) int { return v }, func(v int) int { return v }).Collect()
+		}()
+
+		select {
+		case result := <-done:
+			assert.Empty(t, result, "WindowJoinBy should stop on cancellation without results")
+		case <-time.After(2 * time.Second):
+			t.Fatal("WindowJoinBy should stop when the context is cancelled")
+		}
+	})
+
+	t.Run("EarlyTermination", func(t *testing.T) {
+		t.Parallel()
+		s1 := PairsOf(NewPair("a", 1), NewPair("a", 2), NewPair("a", 3))
+		s2 := PairsOf(NewPair("a", "x"), NewPair("a", "y"))
+
+		result := WindowJoin(context.Background(), s1, s2).Limit(1).Collect()
+		assert.Len(t, result, 1, "WindowJoin should respect Limit")
+	})
+}
+
+// --- Join Statistics Tests ---
+
+func TestJoinStats(t *testing.T) {
+	t.Parallel()
+	t.Run("InnerJoinReportsSkew", func(t *testing.T) {
+		t.Parallel()
+		s1 := PairsOf(NewPair("a", 1), NewPair("b", 2), NewPair("z", 3), NewPair("z", 4))
+		s2 := PairsOf(
+			NewPair("a", "x"), NewPair("a", "y"), NewPair("a", "w"),
+			NewPair("b", "x"), NewPair("c", "y"), NewPair("c", "z"),
+		)
+
+		var stats JoinStats[string]
+		calls := 0
+		result := InnerJoin(s1, s2, WithJoinStats(2, func(st JoinStats[string]) {
+			stats = st
+			calls++
+		})).Collect()
+
+		assert.Len(t, result, 4, "InnerJoin with stats should produce the same rows")
+		assert.Equal(t, 1, calls, "Stats hook should be called once")
+		assert.Equal(t, 6, stats.BuildSize, "BuildSize should count right elements")
+		assert.Equal(t, 3, stats.DistinctKeys, "DistinctKeys should count right keys")
+		assert.Equal(t, 3, stats.MaxGroupSize, "MaxGroupSize should be the largest group")
+		assert.Equal(t, []Pair[string, int]{NewPair("a", 3), NewPair("c", 2)}, stats.HotKeys, "HotKeys should list the top 2 keys")
+		assert.Equal(t, 2, stats.UnmatchedLeft, "UnmatchedLeft should count left rows without a match")
+		assert.Equal(t, 2, stats.UnmatchedRight, "UnmatchedRight should count right rows without a match")
+		assert.GreaterOrEqual(t, stats.Elapsed, time.Duration(0), "Elapsed should be set")
+	})
+
+	t.Run("RightJoinBuildsLeft", func(t *testing.T) {
+		t.Parallel()
+		s1 := PairsOf(NewPair("a", 1), NewPair("a", 2), NewPair("b", 3))
+		s2 := PairsOf(NewPair("a", "x"), NewPair("c", "y"))
+
+		var stats JoinStats[string]
+		RightJoin(s1, s2, WithJoinStats(0, func(st JoinStats[string]) { stats = st })).Collect()
+
+		assert.Equal(t, 3, stats.BuildSize, "RightJoin should build the left side")
+		assert.Equal(t, 1, stats.UnmatchedLeft, "RightJoin UnmatchedLeft should count unmatched build rows")
+		assert.Equal(t, 1, stats.UnmatchedRight, "RightJoin UnmatchedRight should count unmatched probe rows")
+		assert.Empty(t, stats.HotKeys, "HotKeys should be empty when topN is 0")
+	})
+
+	t.Run("CoGroup", func(t *testing.T) {
+		t.Parallel()
+		s1 := PairsOf(NewPair("a", 1), NewPair("b", 2), NewPair("b", 3))
+		s2 := PairsOf(NewPair("a", "x"), NewPair("c", "y"))
+
+		var stats JoinStats[string]
+		CoGroup(s1, s2, WithJoinStats(1, func(st JoinStats[string]) { stats = st })).Collect()
+
+		assert.Equal(t, 2, stats.DistinctKeys, "CoGroup DistinctKeys should count right keys")
+		assert.Equal(t, 2, stats.UnmatchedLeft, "CoGroup UnmatchedLeft should count left-only rows")
+		assert.Equal(t, 1, stats.UnmatchedRight, "CoGroup UnmatchedRight should count right-only rows")
+		assert.Len(t, stats.HotKeys, 1, "CoGroup should report one hot key")
+	})
+
+	t.Run("SemiJoinByCountsDuplicates", func(t *testing.T) {
+		t.Parallel()
+		var stats JoinStats[int]
+		result := SemiJoinBy(Of(1, 2, 3), Of(2, 2, 4),
+			func(v int) int { return v }, func(v int) int { return v },
+			WithJoinStats(1, func(st JoinStats[int]) { stats = st })).Collect()
+
+		assert.Equal(t, []int{2}, result, "SemiJoinBy with stats should keep matching rows")
+		assert.Equal(t, 3, stats.BuildSize, "SemiJoinBy BuildSize should count duplicates")
+		assert.Equal(t, []Pair[int, int]{NewPair(2, 2)}, stats.HotKeys, "SemiJoinBy should report the hottest key")
+		assert.Equal(t, 2, stats.UnmatchedLeft, "SemiJoinBy UnmatchedLeft should be 2")
+		assert.Equal(t, 1, stats.UnmatchedRight, "SemiJoinBy UnmatchedRight should be 1")
+	})
+
+	t.Run("ReportedOnEarlyTermination", func(t *testing.T) {
+		t.Parallel()
+		calls := 0
+		JoinBy(Of(1, 2, 3), Of(1, 2, 3), func(v int) int { return v }, func(v int) int { return v },
+			WithJoinStats(3, func(JoinStats[int]) { calls++ })).Limit(1).Collect()
+
+		assert.Equal(t, 1, calls, "Stats hook should be called when the consumer stops early")
+	})
+}
+
+// --- Similarity Join Tests ---
+
+func TestSimilarityJoinJaccard(t *testing.T) {
+	t.Parallel()
+	identity := func(s string) string { return s }
+
+	t.Run("MatchesAboveThreshold", func(t *testing.T) {
+		t.Parallel()
+		left := Of("acme corp", "globex")
+		right := Of("acme corp.", "acme inc", "initech")
+
+		result := SimilarityJoinJaccard(left, right, identity, identity, 2, 0.6).Collect()
+
+		assert.Len(t, result, 1, "SimilarityJoinJaccard should keep only similar keys")
+		assert.Equal(t, "acme corp", result[0].Left, "SimilarityJoinJaccard Left should match")
+		assert.Equal(t, "acme corp.", result[0].Right, "SimilarityJoinJaccard Right should match")
+		assert.InDelta(t, 8.0/9.0, result[0].Score, 1e-9, "SimilarityJoinJaccard Score should be the Jaccard index")
+	})
+
+	t.Run("IdenticalKeysScoreOne", func(t *testing.T) {
+		t.Parallel()
+		result := SimilarityJoinJaccard(Of("ab"), Of("ab", "cd"), identity, identity, 3, 1.0).Collect()
+
+		assert.Len(t, result, 1, "SimilarityJoinJaccard should match identical short keys")
+		assert.Equal(t, 1.0, result[0].Score, "Identical keys should score 1")
+	})
+
+	t.Run("EmptyKeysNeverMatch", func(t *testing.T) {
+		t.Parallel()
+		result := SimilarityJoinJaccard(Of(""), Of(""), identity, identity, 2, 0.1).Collect()
+		assert.Empty(t, result, "Empty keys have no n-grams to match")
+	})
+
+	t.Run("EarlyTermination", func(t *testing.T) {
+		t.Parallel()
+		result := SimilarityJoinJaccard(Of("abc", "abc"), Of("abc"), identity, identity, 2, 0.5).Limit(1).Collect()
+		assert.Len(t, result, 1, "SimilarityJoinJaccard should respect Limit")
+	})
+}
+
+func TestSimilarityJoinEditDistance(t *testing.T) {
+	t.Parallel()
+	identity := func(s string) string { return s }
+
+	t.Run("MatchesWithinDistance", func(t *testing.T) {
+		t.Parallel()
+		type Vendor struct {
+			ID   int
+			Name string
+		}
+		left := Of(Vendor{1, "Microsoft"}, Vendor{2, "Oracle"})
+		right := Of(Vendor{10, "Mircosoft"}, Vendor{11, "Orcale"}, Vendor{12, "Apple"})
+		name := func(v Vendor) string { return v.Name }
+
+		result := SimilarityJoinEditDistance(left, right, name, name, 2).Collect()
+
+		assert.Len(t, result, 2, "SimilarityJoinEditDistance should match transposed names")
+		assert.Equal(t, 10, result[0].Right.ID, "Microsoft should match Mircosoft")
+		assert.Equal(t, 11, result[1].Right.ID, "Oracle should match Orcale")
+		assert.InDelta(t, 1-2.0/9.0, result[0].Score, 1e-9, "Score should be 1 - distance/maxLen")
+	})
+
+	t.Run("ShortKeysWithoutSharedGrams", func(t *testing.T) {
+		t.Parallel()
+		result := SimilarityJoinEditDistance(Of("ab", "x"), Of("ba", "y", "abcd"), identity, identity, 2).Collect()
+
+		pairs := make([]string, len(result))
+		for i, m := range result {
+			pairs[i] = m.Left + "-" + m.Right
+		}
+		assert.Equal(t, []string{"ab-ba", "ab-y", "ab-abcd", "x-ba", "x-y"}, pairs,
+			"SimilarityJoinEditDistance should not miss short keys")
+	})
+
+	t.Run("MatchesBruteForce", func(t *testing.T) {
+		t.Parallel()
+		words := []string{"kitten", "sitting", "mitten", "smitten", "bitten", "written", "kitchen", "kit", "", "k"}
+		for d := 0; d <= 3; d++ {
+			expected := 0
+			for _, a := range words {
+				for _, b := range words {
+					if levenshtein([]rune(a), []rune(b)) <= d {
+						expected++
+					}
+				}
+			}
+			result := SimilarityJoinEditDistance(FromSlice(words), FromSlice(words), identity, identity, d).Count()
+			assert.Equal(t, expected, result, "SimilarityJoinEditDistance should match brute force for d=%d", d)
+		}
+	})
+
+	t.Run("Levenshtein", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, 3, levenshtein([]rune("kitten"), []rune("sitting")), "kitten/sitting distance should be 3")
+		assert.Equal(t, 1, levenshtein([]rune("café"), []rune("cafe")), "Distance should count runes")
+		assert.Equal(t, 3, levenshtein([]rune(""), []rune("abc")), "Distance to empty should be length")
+	})
+}
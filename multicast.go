@@ -0,0 +1,261 @@
+package streams
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// --- Multicasting ---
+//
+// A Stream is a single-pass iter.Seq, so a source such as FromChannel or FromReaderLines
+// cannot feed two pipelines directly. Tee and Share pull the source once in a background
+// goroutine and deliver every element to several consumers, each through its own bounded
+// buffer. What happens when a consumer's buffer is full is decided by the LagPolicy.
+
+// LagPolicy decides what happens when a consumer's buffer is full.
+type LagPolicy int
+
+const (
+	// LagBlock makes the source wait until the lagging consumer has room (backpressure).
+	// The fastest consumer can then run at most BufferSize elements ahead of the slowest.
+	LagBlock LagPolicy = iota
+	// LagDrop drops elements for the lagging consumer; other consumers are unaffected.
+	LagDrop
+	// LagError disconnects the lagging consumer. Its stream ends, or yields
+	// ErrConsumerLagged for the Result variants; other consumers are unaffected.
+	LagError
+)
+
+// ErrConsumerLagged is yielded to a consumer that was disconnected under LagError.
+var ErrConsumerLagged = errors.New("streams: consumer lagged behind")
+
+// TeeConfig holds configuration for Tee and Share.
+type TeeConfig struct {
+	BufferSize int       // Per-consumer buffer size
+	LagPolicy  LagPolicy // What to do when a consumer's buffer is full
+}
+
+// DefaultTeeConfig returns the default multicast configuration.
+func DefaultTeeConfig() TeeConfig {
+	return TeeConfig{
+		BufferSize: 64,
+		LagPolicy:  LagBlock,
+	}
+}
+
+// TeeOption is a function that modifies TeeConfig.
+type TeeOption func(*TeeConfig)
+
+// WithTeeBufferSize sets the per-consumer buffer size.
+func WithTeeBufferSize(size int) TeeOption {
+	return func(c *TeeConfig) {
+		if size > 0 {
+			c.BufferSize = size
+		}
+	}
+}
+
+// WithLagPolicy sets the policy applied when a consumer's buffer is full.
+func WithLagPolicy(p LagPolicy) TeeOption {
+	return func(c *TeeConfig) {
+		c.LagPolicy = p
+	}
+}
+
+// teeBranch is one consumer of a broadcastHub.
+type teeBranch[T any] struct {
+	ch       chan T
+	quit     chan struct{}
+	quitOnce sync.Once
+	used     atomic.Bool
+	lagged   bool // written by the pump before closing ch
+}
+
+// broadcastHub pulls the source once and fans elements out to its subscribed branches.
+type broadcastHub[T any] struct {
+	src   Stream[T]
+	cfg   TeeConfig
+	start sync.Once
+	mu    sync.Mutex
+	subs  []*teeBranch[T]
+	done  bool
+}
+
+func newBroadcastHub[T any](s Stream[T], opts []TeeOption) *broadcastHub[T] {
+	cfg := DefaultTeeConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &broadcastHub[T]{src: s, cfg: cfg}
+}
+
+// subscribe registers a new branch, or returns nil if the source has already ended.
+func (h *broadcastHub[T]) subscribe() *teeBranch[T] {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.done {
+		return nil
+	}
+	b := &teeBranch[T]{
+		ch:   make(chan T, h.cfg.BufferSize),
+		quit: make(chan struct{}),
+	}
+	h.subs = append(h.subs, b)
+	return b
+}
+
+// unsubscribe detaches a branch whose consumer stopped early.
+func (h *broadcastHub[T]) unsubscribe(b *teeBranch[T]) {
+	b.quitOnce.Do(func() { close(b.quit) })
+	h.mu.Lock()
+	h.subs = slices.DeleteFunc(h.subs, func(x *teeBranch[T]) bool { return x == b })
+	h.mu.Unlock()
+}
+
+// send delivers v to b according to the lag policy.
+// It returns false if b lagged under LagError and must be disconnected.
+func (h *broadcastHub[T]) send(b *teeBranch[T], v T) bool {
+	switch h.cfg.LagPolicy {
+	case LagDrop:
+		select {
+		case b.ch <- v:
+		case <-b.quit:
+		default:
+		}
+	case LagError:
+		select {
+		case b.ch <- v:
+		case <-b.quit:
+		default:
+			return false
+		}
+	default:
+		select {
+		case b.ch <- v:
+		case <-b.quit:
+		}
+	}
+	return true
+}
+
+// run pumps the source until it ends or no subscribers are left, then completes all branches.
+func (h *broadcastHub[T]) run() {
+	defer func() {
+		h.mu.Lock()
+		h.done = true
+		for _, b := range h.subs {
+			close(b.ch)
+		}
+		h.subs = nil
+		h.mu.Unlock()
+	}()
+	for v := range h.src.seq {
+		h.mu.Lock()
+		subs := slices.Clone(h.subs)
+		h.mu.Unlock()
+		if len(subs) == 0 {
+			return
+		}
+		for _, b := range subs {
+			if h.send(b, v) {
+				continue
+			}
+			h.mu.Lock()
+			h.subs = slices.DeleteFunc(h.subs, func(x *teeBranch[T]) bool { return x == b })
+			h.mu.Unlock()
+			b.lagged = true
+			close(b.ch)
+		}
+	}
+}
+
+// stream returns a consumer stream for the branch returned by attach.
+// The first consumer to start iterating starts the pump.
+func (h *broadcastHub[T]) stream(attach func() *teeBranch[T]) Stream[Result[T]] {
+	return Stream[Result[T]]{
+		seq: func(yield func(Result[T]) bool) {
+			b := attach()
+			if b == nil {
+				return
+			}
+			h.start.Do(func() { go h.run() })
+			for v := range b.ch {
+				if !yield(Ok(v)) {
+					h.unsubscribe(b)
+					return
+				}
+			}
+			if b.lagged {
+				yield(Err[T](ErrConsumerLagged))
+			}
+		},
+	}
+}
+
+// Tee splits a single-pass stream into n streams that each see every element.
+// The source is pulled once, in a goroutine started when the first branch is iterated;
+// each branch has its own bounded buffer, and the LagPolicy decides what happens when
+// it fills (LagBlock by default). Under LagBlock the branches must be consumed
+// concurrently, and every branch must be iterated (breaking out early is fine),
+// otherwise the source stalls once that branch's buffer is full.
+// The source is stopped when it ends or when every branch has stopped early.
+// Each branch can be iterated only once. If n <= 0, returns nil.
+func Tee[T any](s Stream[T], n int, opts ...TeeOption) []Stream[T] {
+	if n <= 0 {
+		return nil
+	}
+	branches := TeeErr(s, n, opts...)
+	result := make([]Stream[T], len(branches))
+	for i, b := range branches {
+		result[i] = TakeUntilErr(b)
+	}
+	return result
+}
+
+// TeeErr is like Tee but yields Results, so a branch disconnected under LagError
+// ends with Err(ErrConsumerLagged) instead of ending silently.
+func TeeErr[T any](s Stream[T], n int, opts ...TeeOption) []Stream[Result[T]] {
+	if n <= 0 {
+		return nil
+	}
+	h := newBroadcastHub(s, opts)
+	result := make([]Stream[Result[T]], n)
+	for i := range n {
+		b := h.subscribe()
+		result[i] = h.stream(func() *teeBranch[T] {
+			if b.used.Swap(true) {
+				return nil
+			}
+			return b
+		})
+	}
+	return result
+}
+
+// Broadcast shares one pass over a source between any number of subscribers,
+// including ones that attach after it has started. Create it with Share.
+type Broadcast[T any] struct {
+	hub *broadcastHub[T]
+}
+
+// Share returns a Broadcast over s. The source starts when the first subscriber begins
+// iterating. A subscriber receives the elements produced after it starts iterating,
+// through its own bounded buffer governed by the LagPolicy.
+// The source is stopped when it ends or when no subscribers are left; subscribers that
+// start after that receive an empty stream.
+func Share[T any](s Stream[T], opts ...TeeOption) *Broadcast[T] {
+	return &Broadcast[T]{hub: newBroadcastHub(s, opts)}
+}
+
+// Subscribe returns a stream that attaches to the broadcast when iterated.
+func (b *Broadcast[T]) Subscribe() Stream[T] {
+	return TakeUntilErr(b.SubscribeErr())
+}
+
+// SubscribeErr is like Subscribe but ends with Err(ErrConsumerLagged) if the subscriber
+// is disconnected under LagError.
+func (b *Broadcast[T]) SubscribeErr() Stream[Result[T]] {
+	return b.hub.stream(b.hub.subscribe)
+}
@@ -0,0 +1,155 @@
+package streams
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collectConcurrently collects every stream in its own goroutine.
+func collectConcurrently[T any](streams []Stream[T]) [][]T {
+	results := make([][]T, len(streams))
+	var wg sync.WaitGroup
+	for i, s := range streams {
+		wg.Go(func() { results[i] = s.Collect() })
+	}
+	wg.Wait()
+	return results
+}
+
+func TestTee(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SingleSourceManyConsumers", func(t *testing.T) {
+		t.Parallel()
+		ch := make(chan int)
+		go func() {
+			defer close(ch)
+			for i := range 100 {
+				ch <- i
+			}
+		}()
+		branches := Tee(FromChannel(ch), 3, WithTeeBufferSize(4))
+		require.Len(t, branches, 3)
+		want := Range(0, 100).Collect()
+		for i, got := range collectConcurrently(branches) {
+			assert.Equal(t, want, got, "Branch %d should see every element", i)
+		}
+	})
+
+	t.Run("DifferentPipelines", func(t *testing.T) {
+		t.Parallel()
+		branches := Tee(Range(1, 11), 2)
+		var (
+			sum  int
+			odds []int
+			wg   sync.WaitGroup
+		)
+		wg.Go(func() { sum = Sum(branches[0]) })
+		wg.Go(func() { odds = branches[1].Filter(func(n int) bool { return n%2 == 1 }).Collect() })
+		wg.Wait()
+		assert.Equal(t, 55, sum)
+		assert.Equal(t, []int{1, 3, 5, 7, 9}, odds)
+	})
+
+	t.Run("EarlyStop", func(t *testing.T) {
+		t.Parallel()
+		pulled := 0
+		src := Iterate(0, func(n int) int { return n + 1 }).Peek(func(int) { pulled++ })
+		branches := Tee(src, 2, WithTeeBufferSize(1))
+		results := make([][]int, 2)
+		var wg sync.WaitGroup
+		wg.Go(func() { results[0] = branches[0].Limit(3).Collect() })
+		wg.Go(func() { results[1] = branches[1].Limit(50).Collect() })
+		wg.Wait()
+		assert.Equal(t, []int{0, 1, 2}, results[0])
+		assert.Len(t, results[1], 50, "A stopped branch should not block the others")
+		assert.Empty(t, branches[0].Collect(), "A branch can only be iterated once")
+	})
+
+	// pacedSource yields 0..n-1, sending the next element only after ack receives a value,
+	// so a consumer that acks every element never lags.
+	pacedSource := func(n int) (Stream[int], chan struct{}) {
+		ch := make(chan int)
+		ack := make(chan struct{})
+		go func() {
+			defer close(ch)
+			for i := range n {
+				ch <- i
+				<-ack
+			}
+		}()
+		return FromChannel(ch), ack
+	}
+
+	t.Run("LagDrop", func(t *testing.T) {
+		t.Parallel()
+		src, ack := pacedSource(20)
+		branches := Tee(src, 2, WithTeeBufferSize(2), WithLagPolicy(LagDrop))
+		fast := branches[0].Peek(func(int) { ack <- struct{}{} }).Collect()
+		assert.Equal(t, Range(0, 20).Collect(), fast, "The fast branch should not be slowed by the lagging one")
+		assert.Equal(t, []int{0, 1}, branches[1].Collect(), "The lagging branch should miss elements once its buffer is full")
+	})
+
+	t.Run("LagError", func(t *testing.T) {
+		t.Parallel()
+		src, ack := pacedSource(20)
+		branches := TeeErr(src, 2, WithTeeBufferSize(2), WithLagPolicy(LagError))
+		fast, err := CollectResults(branches[0].Peek(func(Result[int]) { ack <- struct{}{} }))
+		require.NoError(t, err)
+		assert.Len(t, fast, 20)
+		slow, err := CollectResults(branches[1])
+		assert.ErrorIs(t, err, ErrConsumerLagged, "The lagging branch should be disconnected with an error")
+		assert.Equal(t, []int{0, 1}, slow, "The lagging branch should keep its buffered elements")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, Tee(Of(1, 2), 0))
+	})
+}
+
+func TestShare(t *testing.T) {
+	t.Parallel()
+
+	t.Run("LateSubscriber", func(t *testing.T) {
+		t.Parallel()
+		ch := make(chan int)
+		shared := Share(FromChannel(ch))
+
+		seen := make(chan int, 4)
+		first := make(chan []int)
+		go func() { first <- shared.Subscribe().Peek(func(v int) { seen <- v }).Collect() }()
+		ch <- 1
+		ch <- 2
+		<-seen
+		<-seen // 2 has been fanned out, so the late subscriber cannot receive it
+
+		late := make(chan []int)
+		go func() { late <- shared.Subscribe().Collect() }()
+		assert.Eventually(t, func() bool {
+			shared.hub.mu.Lock()
+			defer shared.hub.mu.Unlock()
+			return len(shared.hub.subs) == 2
+		}, time.Second, time.Millisecond, "The late subscriber should attach")
+		ch <- 3
+		ch <- 4
+		close(ch)
+
+		assert.Equal(t, []int{1, 2, 3, 4}, <-first, "The first subscriber should see every element")
+		assert.Equal(t, []int{3, 4}, <-late, "A late subscriber should see elements after it attached")
+		assert.Empty(t, shared.Subscribe().Collect(), "Subscribers after the source ended should get an empty stream")
+	})
+
+	t.Run("StopsWhenAllLeave", func(t *testing.T) {
+		t.Parallel()
+		shared := Share(Iterate(0, func(n int) int { return n + 1 }))
+		assert.Equal(t, []int{0, 1, 2}, shared.Subscribe().Limit(3).Collect())
+		assert.Eventually(t, func() bool {
+			return shared.Subscribe().Limit(1).Count() == 0
+		}, time.Second, 5*time.Millisecond, "The source should stop once no subscribers are left")
+	})
+}
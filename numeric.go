@@ -1,6 +1,12 @@
 package streams
 
-import "cmp"
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+	"time"
+)
 
 // Numeric is a constraint that includes all numeric types.
 type Numeric interface {
@@ -40,6 +46,65 @@ func Sum[T Numeric](s Stream[T]) T {
 	return sum
 }
 
+// ErrOverflow is returned when checked integer arithmetic overflows.
+var ErrOverflow = errors.New("streams: integer overflow")
+
+// neumaierSum is a compensated float64 accumulator (Neumaier's improved Kahan summation).
+type neumaierSum struct {
+	sum, comp float64
+}
+
+// add adds x, capturing the low-order bits lost to rounding in comp.
+func (n *neumaierSum) add(x float64) {
+	t := n.sum + x
+	if math.Abs(n.sum) >= math.Abs(x) {
+		n.comp += (n.sum - t) + x
+	} else {
+		n.comp += (x - t) + n.sum
+	}
+	n.sum = t
+}
+
+// value returns the compensated sum.
+func (n *neumaierSum) value() float64 {
+	return n.sum + n.comp
+}
+
+// SumKahan returns the sum of floating-point elements using Neumaier's variant of Kahan
+// compensated summation, which keeps the error independent of the number of elements
+// and also handles terms larger than the running sum.
+func SumKahan[T Float](s Stream[T]) T {
+	var n neumaierSum
+	for v := range s.seq {
+		n.add(float64(v))
+	}
+	return T(n.value())
+}
+
+// SumChecked returns the sum of integer elements, or ErrOverflow if the sum
+// overflows T at any point. Stops consuming the stream at the first overflow.
+func SumChecked[T Integer](s Stream[T]) Result[T] {
+	var sum T
+	for v := range s.seq {
+		next, ok := addChecked(sum, v)
+		if !ok {
+			return Err[T](ErrOverflow)
+		}
+		sum = next
+	}
+	return Ok(sum)
+}
+
+// addChecked returns a+b and whether the addition did not overflow T.
+func addChecked[T Integer](a, b T) (T, bool) {
+	r := a + b
+	var zero T
+	if ^zero < 0 { // signed
+		return r, (b >= 0) == (r >= a)
+	}
+	return r, r >= a
+}
+
 // Average returns the average of all numeric elements.
 // Returns None for an empty stream.
 func Average[T Numeric](s Stream[T]) Optional[float64] {
@@ -204,15 +269,44 @@ type Statistics[T Numeric] struct {
 	Average float64
 }
 
+// StatisticsConfig configures GetStatistics.
+type StatisticsConfig struct {
+	// CompensatedSum uses Neumaier summation for floating-point elements.
+	CompensatedSum bool
+}
+
+// StatisticsOption configures GetStatistics.
+type StatisticsOption func(*StatisticsConfig)
+
+// WithCompensatedSum makes GetStatistics sum floating-point elements with Neumaier
+// compensated summation, like SumKahan. It has no effect for integer elements.
+func WithCompensatedSum() StatisticsOption {
+	return func(cfg *StatisticsConfig) {
+		cfg.CompensatedSum = true
+	}
+}
+
 // GetStatistics computes basic statistics for a numeric stream.
 // Returns None for an empty stream.
-func GetStatistics[T Numeric](s Stream[T]) Optional[Statistics[T]] {
+func GetStatistics[T Numeric](s Stream[T], opts ...StatisticsOption) Optional[Statistics[T]] {
+	var cfg StatisticsConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	half := 0.5
+	compensated := cfg.CompensatedSum && T(half) != 0 // only floats keep the fraction
+
 	var sum, min, max T
+	var csum neumaierSum
 	count := 0
 	first := true
 
 	for v := range s.seq {
-		sum += v
+		if compensated {
+			csum.add(float64(v))
+		} else {
+			sum += v
+		}
 		count++
 		if first {
 			min = v
@@ -232,15 +326,527 @@ func GetStatistics[T Numeric](s Stream[T]) Optional[Statistics[T]] {
 		return None[Statistics[T]]()
 	}
 
+	average := float64(sum) / float64(count)
+	if compensated {
+		sum = T(csum.value())
+		average = csum.value() / float64(count)
+	}
 	return Some(Statistics[T]{
 		Count:   count,
 		Sum:     sum,
 		Min:     min,
 		Max:     max,
-		Average: float64(sum) / float64(count),
+		Average: average,
 	})
 }
 
+// DescriptiveStatistics holds descriptive statistics about a numeric stream.
+// Skewness and Kurtosis are the population (biased) estimators; Kurtosis is the excess
+// kurtosis, which is 0 for a normal distribution. Statistics that are undefined for the
+// sample (e.g. SampleVariance of a single value, Skewness of constant values) are NaN.
+// Sum uses compensated summation.
+type DescriptiveStatistics struct {
+	Count              int
+	Sum                float64
+	Min                float64
+	Max                float64
+	Mean               float64
+	PopulationVariance float64
+	SampleVariance     float64
+	PopulationStdDev   float64
+	SampleStdDev       float64
+	Skewness           float64
+	Kurtosis           float64
+}
+
+// momentsState holds online central moments, updated with Welford's method and
+// merged with Pébay's pairwise formulas so partial results can be combined.
+type momentsState struct {
+	n          float64
+	mean       float64
+	m2, m3, m4 float64
+	sum        neumaierSum
+	min, max   float64
+}
+
+// add updates the moments with a single value.
+func (m *momentsState) add(x float64) {
+	n1 := m.n
+	m.n++
+	delta := x - m.mean
+	deltaN := delta / m.n
+	deltaN2 := deltaN * deltaN
+	term1 := delta * deltaN * n1
+	m.mean += deltaN
+	m.m4 += term1*deltaN2*(m.n*m.n-3*m.n+3) + 6*deltaN2*m.m2 - 4*deltaN*m.m3
+	m.m3 += term1*deltaN*(m.n-2) - 3*deltaN*m.m2
+	m.m2 += term1
+	m.sum.add(x)
+	if n1 == 0 {
+		m.min, m.max = x, x
+	} else {
+		m.min = min(m.min, x)
+		m.max = max(m.max, x)
+	}
+}
+
+// merge combines the moments of o into m.
+func (m *momentsState) merge(o *momentsState) {
+	if o.n == 0 {
+		return
+	}
+	if m.n == 0 {
+		*m = *o
+		return
+	}
+	na, nb := m.n, o.n
+	n := na + nb
+	delta := o.mean - m.mean
+	delta2 := delta * delta
+	m4 := m.m4 + o.m4 +
+		delta2*delta2*na*nb*(na*na-na*nb+nb*nb)/(n*n*n) +
+		6*delta2*(na*na*o.m2+nb*nb*m.m2)/(n*n) +
+		4*delta*(na*o.m3-nb*m.m3)/n
+	m3 := m.m3 + o.m3 +
+		delta2*delta*na*nb*(na-nb)/(n*n) +
+		3*delta*(na*o.m2-nb*m.m2)/n
+	m.m2 += o.m2 + delta2*na*nb/n
+	m.m3, m.m4 = m3, m4
+	m.mean += delta * nb / n
+	m.n = n
+	m.sum.add(o.sum.value())
+	m.min = min(m.min, o.min)
+	m.max = max(m.max, o.max)
+}
+
+// result converts the moments to DescriptiveStatistics.
+func (m *momentsState) result() DescriptiveStatistics {
+	popVar := m.m2 / m.n
+	sampleVar := math.NaN()
+	if m.n > 1 {
+		sampleVar = m.m2 / (m.n - 1)
+	}
+	skew, kurt := math.NaN(), math.NaN()
+	if m.m2 > 0 {
+		skew = math.Sqrt(m.n) * m.m3 / math.Pow(m.m2, 1.5)
+		kurt = m.n*m.m4/(m.m2*m.m2) - 3
+	}
+	return DescriptiveStatistics{
+		Count:              int(m.n),
+		Sum:                m.sum.value(),
+		Min:                m.min,
+		Max:                m.max,
+		Mean:               m.mean,
+		PopulationVariance: popVar,
+		SampleVariance:     sampleVar,
+		PopulationStdDev:   math.Sqrt(popVar),
+		SampleStdDev:       math.Sqrt(sampleVar),
+		Skewness:           skew,
+		Kurtosis:           kurt,
+	}
+}
+
+// DescriptiveStatisticsCollector returns a Collector that computes DescriptiveStatistics
+// in one pass with numerically stable online updates. The state is mergeable, so the
+// collector can be used with ParallelCollectTo and as a per-group downstream.
+// Returns None for an empty stream.
+func DescriptiveStatisticsCollector[T Numeric]() Collector[T, *momentsState, Optional[DescriptiveStatistics]] {
+	return Collector[T, *momentsState, Optional[DescriptiveStatistics]]{
+		Supplier: func() *momentsState { return &momentsState{} },
+		Accumulator: func(m *momentsState, v T) *momentsState {
+			m.add(float64(v))
+			return m
+		},
+		Combiner: func(a, b *momentsState) *momentsState {
+			a.merge(b)
+			return a
+		},
+		Finisher: func(m *momentsState) Optional[DescriptiveStatistics] {
+			if m.n == 0 {
+				return None[DescriptiveStatistics]()
+			}
+			return Some(m.result())
+		},
+	}
+}
+
+// GetDescriptiveStatistics computes DescriptiveStatistics for a numeric stream in one pass.
+// Returns None for an empty stream.
+func GetDescriptiveStatistics[T Numeric](s Stream[T]) Optional[DescriptiveStatistics] {
+	return CollectTo(s, DescriptiveStatisticsCollector[T]())
+}
+
+// --- Binned Histograms ---
+
+// LinearBuckets returns count upper bounds start, start+width, start+2*width, ...
+// for use with BinnedHistogramCollector. Returns nil if count <= 0 or width <= 0.
+func LinearBuckets(start, width float64, count int) []float64 {
+	if count <= 0 || !(width > 0) {
+		return nil
+	}
+	bounds := make([]float64, count)
+	for i := range bounds {
+		bounds[i] = start + float64(i)*width
+	}
+	return bounds
+}
+
+// ExponentialBuckets returns count upper bounds start, start*factor, start*factor^2, ...
+// for use with BinnedHistogramCollector. Returns nil if count <= 0, start <= 0 or factor <= 1.
+func ExponentialBuckets(start, factor float64, count int) []float64 {
+	if count <= 0 || !(start > 0) || !(factor > 1) {
+		return nil
+	}
+	bounds := make([]float64, count)
+	for i := range bounds {
+		bounds[i] = start * math.Pow(factor, float64(i))
+	}
+	return bounds
+}
+
+// HistogramBucket is a bucket of a BinnedHistogram holding values in (previous UpperBound, UpperBound].
+type HistogramBucket struct {
+	UpperBound float64
+	Count      int64
+}
+
+// BinnedHistogram counts numeric values into buckets with fixed upper bounds, plus an
+// overflow bucket with an upper bound of +Inf, and tracks their count, sum, min and max.
+// Histograms with the same bounds can be merged.
+type BinnedHistogram struct {
+	bounds   []float64
+	counts   []int64 // len(bounds)+1; the last is the +Inf bucket
+	count    int64
+	sum      float64
+	min, max float64
+}
+
+// NewBinnedHistogram creates an empty histogram with the given bucket upper bounds.
+// Bounds are sorted and deduplicated; NaN and infinite bounds are dropped.
+func NewBinnedHistogram(bounds []float64) *BinnedHistogram {
+	clean := make([]float64, 0, len(bounds))
+	for _, b := range bounds {
+		if !math.IsNaN(b) && !math.IsInf(b, 0) {
+			clean = append(clean, b)
+		}
+	}
+	slices.Sort(clean)
+	clean = slices.Compact(clean)
+	return &BinnedHistogram{
+		bounds: clean,
+		counts: make([]int64, len(clean)+1),
+		min:    math.Inf(1),
+		max:    math.Inf(-1),
+	}
+}
+
+// Add counts x into its bucket. NaN values are ignored.
+func (h *BinnedHistogram) Add(x float64) {
+	if math.IsNaN(x) {
+		return
+	}
+	i, _ := slices.BinarySearch(h.bounds, x)
+	h.counts[i]++
+	h.count++
+	h.sum += x
+	h.min = min(h.min, x)
+	h.max = max(h.max, x)
+}
+
+// Merge adds the counts of other into h.
+// Returns ErrInvalidSketch if the bounds differ.
+func (h *BinnedHistogram) Merge(other *BinnedHistogram) error {
+	if other == nil {
+		return nil
+	}
+	if !slices.Equal(h.bounds, other.bounds) {
+		return ErrInvalidSketch
+	}
+	for i, c := range other.counts {
+		h.counts[i] += c
+	}
+	h.count += other.count
+	h.sum += other.sum
+	h.min = min(h.min, other.min)
+	h.max = max(h.max, other.max)
+	return nil
+}
+
+// Buckets returns the per-bucket (non-cumulative) counts, ending with the +Inf bucket.
+func (h *BinnedHistogram) Buckets() []HistogramBucket {
+	result := make([]HistogramBucket, len(h.counts))
+	for i, c := range h.counts {
+		upper := math.Inf(1)
+		if i < len(h.bounds) {
+			upper = h.bounds[i]
+		}
+		result[i] = HistogramBucket{UpperBound: upper, Count: c}
+	}
+	return result
+}
+
+// Count returns the number of values added.
+func (h *BinnedHistogram) Count() int64 {
+	return h.count
+}
+
+// Sum returns the sum of the values added.
+func (h *BinnedHistogram) Sum() float64 {
+	return h.sum
+}
+
+// Min returns the smallest value added, or None if the histogram is empty.
+func (h *BinnedHistogram) Min() Optional[float64] {
+	return OptionalFromCondition(h.count > 0, h.min)
+}
+
+// Max returns the largest value added, or None if the histogram is empty.
+func (h *BinnedHistogram) Max() Optional[float64] {
+	return OptionalFromCondition(h.count > 0, h.max)
+}
+
+// Quantile estimates the q-th quantile (q in [0, 1]) from the bucket counts by linear
+// interpolation within the bucket containing the rank. The observed min and max bound the
+// first and overflow buckets. Returns None if the histogram is empty.
+func (h *BinnedHistogram) Quantile(q float64) Optional[float64] {
+	if h.count == 0 {
+		return None[float64]()
+	}
+	q = min(max(q, 0), 1)
+	rank := q * float64(h.count)
+	var cum int64
+	for i, c := range h.counts {
+		if c == 0 || float64(cum+c) < rank {
+			cum += c
+			continue
+		}
+		lower, upper := h.min, h.max
+		if i > 0 {
+			lower = max(lower, h.bounds[i-1])
+		}
+		if i < len(h.bounds) {
+			upper = min(upper, h.bounds[i])
+		}
+		return Some(lower + (upper-lower)*(rank-float64(cum))/float64(c))
+	}
+	return Some(h.max)
+}
+
+// BinnedHistogramCollector returns a Collector that counts numeric elements into buckets with
+// the given upper bounds (see LinearBuckets and ExponentialBuckets). Unlike HistogramCollector
+// it keeps only counts, and partial histograms can be merged.
+func BinnedHistogramCollector[T Numeric](bounds []float64) Collector[T, *BinnedHistogram, *BinnedHistogram] {
+	return Collector[T, *BinnedHistogram, *BinnedHistogram]{
+		Supplier: func() *BinnedHistogram { return NewBinnedHistogram(bounds) },
+		Accumulator: func(h *BinnedHistogram, v T) *BinnedHistogram {
+			h.Add(float64(v))
+			return h
+		},
+		Combiner: func(a, b *BinnedHistogram) *BinnedHistogram {
+			_ = a.Merge(b) // same bounds by construction
+			return a
+		},
+		Finisher: func(h *BinnedHistogram) *BinnedHistogram { return h },
+	}
+}
+
+// --- Bivariate Statistics ---
+
+// LinearFit is the result of a simple least-squares regression y = Slope*x + Intercept.
+// RSquared is the coefficient of determination; it is 1 when all y values are equal.
+type LinearFit struct {
+	Count     int
+	Slope     float64
+	Intercept float64
+	RSquared  float64
+}
+
+// Predict returns the fitted y value for x.
+func (f LinearFit) Predict(x float64) float64 {
+	return f.Slope*x + f.Intercept
+}
+
+// bivariateState holds online co-moments of (x, y) pairs, mergeable like momentsState.
+type bivariateState struct {
+	n             float64
+	meanX, meanY  float64
+	sxx, syy, sxy float64 // sums of squared deviations and cross deviations
+}
+
+// add updates the co-moments with a single pair.
+func (b *bivariateState) add(x, y float64) {
+	b.n++
+	dx := x - b.meanX
+	dy := y - b.meanY
+	b.meanX += dx / b.n
+	b.meanY += dy / b.n
+	b.sxx += dx * (x - b.meanX)
+	b.syy += dy * (y - b.meanY)
+	b.sxy += dx * (y - b.meanY)
+}
+
+// merge combines the co-moments of o into b.
+func (b *bivariateState) merge(o *bivariateState) {
+	if o.n == 0 {
+		return
+	}
+	if b.n == 0 {
+		*b = *o
+		return
+	}
+	n := b.n + o.n
+	dx := o.meanX - b.meanX
+	dy := o.meanY - b.meanY
+	f := b.n * o.n / n
+	b.sxx += o.sxx + dx*dx*f
+	b.syy += o.syy + dy*dy*f
+	b.sxy += o.sxy + dx*dy*f
+	b.meanX += dx * o.n / n
+	b.meanY += dy * o.n / n
+	b.n = n
+}
+
+// bivariateCollector returns a Collector over pairs accumulating co-moments,
+// finished by finish.
+func bivariateCollector[X, Y Numeric, R any](finish func(*bivariateState) R) Collector[Pair[X, Y], *bivariateState, R] {
+	return Collector[Pair[X, Y], *bivariateState, R]{
+		Supplier: func() *bivariateState { return &bivariateState{} },
+		Accumulator: func(b *bivariateState, p Pair[X, Y]) *bivariateState {
+			b.add(float64(p.First), float64(p.Second))
+			return b
+		},
+		Combiner: func(a, b *bivariateState) *bivariateState {
+			a.merge(b)
+			return a
+		},
+		Finisher: finish,
+	}
+}
+
+// CovarianceCollector returns a Collector that computes the sample covariance of (x, y) pairs
+// in one pass. Returns None for fewer than two pairs.
+func CovarianceCollector[X, Y Numeric]() Collector[Pair[X, Y], *bivariateState, Optional[float64]] {
+	return bivariateCollector[X, Y](func(b *bivariateState) Optional[float64] {
+		if b.n < 2 {
+			return None[float64]()
+		}
+		return Some(b.sxy / (b.n - 1))
+	})
+}
+
+// PearsonCorrelationCollector returns a Collector that computes the Pearson correlation
+// coefficient of (x, y) pairs in one pass.
+// Returns None for fewer than two pairs or if either variable is constant.
+func PearsonCorrelationCollector[X, Y Numeric]() Collector[Pair[X, Y], *bivariateState, Optional[float64]] {
+	return bivariateCollector[X, Y](func(b *bivariateState) Optional[float64] {
+		if b.n < 2 || b.sxx == 0 || b.syy == 0 {
+			return None[float64]()
+		}
+		return Some(b.sxy / math.Sqrt(b.sxx*b.syy))
+	})
+}
+
+// LinearRegressionCollector returns a Collector that fits y = Slope*x + Intercept
+// by ordinary least squares in one pass.
+// Returns None for fewer than two pairs or if all x values are equal.
+func LinearRegressionCollector[X, Y Numeric]() Collector[Pair[X, Y], *bivariateState, Optional[LinearFit]] {
+	return bivariateCollector[X, Y](func(b *bivariateState) Optional[LinearFit] {
+		if b.n < 2 || b.sxx == 0 {
+			return None[LinearFit]()
+		}
+		slope := b.sxy / b.sxx
+		r2 := 1.0
+		if b.syy > 0 {
+			r2 = b.sxy * b.sxy / (b.sxx * b.syy)
+		}
+		return Some(LinearFit{
+			Count:     int(b.n),
+			Slope:     slope,
+			Intercept: b.meanY - slope*b.meanX,
+			RSquared:  r2,
+		})
+	})
+}
+
+// ranks returns the 1-based ranks of values, averaging the ranks of ties.
+func ranks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int { return cmp.Compare(values[a], values[b]) })
+
+	result := make([]float64, len(values))
+	for i := 0; i < len(order); {
+		j := i + 1
+		for j < len(order) && values[order[j]] == values[order[i]] {
+			j++
+		}
+		avg := float64(i+j+1) / 2 // average of ranks i+1..j
+		for _, idx := range order[i:j] {
+			result[idx] = avg
+		}
+		i = j
+	}
+	return result
+}
+
+// SpearmanCorrelationCollector returns a Collector that computes Spearman's rank correlation
+// of (x, y) pairs, with tied values given their average rank. Ranking needs every pair,
+// so unlike the other bivariate collectors it stores all values.
+// Returns None for fewer than two pairs or if either variable is constant.
+func SpearmanCorrelationCollector[X, Y Numeric]() Collector[Pair[X, Y], []Pair[X, Y], Optional[float64]] {
+	return Collector[Pair[X, Y], []Pair[X, Y], Optional[float64]]{
+		Supplier: func() []Pair[X, Y] { return make([]Pair[X, Y], 0) },
+		Accumulator: func(acc []Pair[X, Y], p Pair[X, Y]) []Pair[X, Y] {
+			return append(acc, p)
+		},
+		Combiner: func(a, b []Pair[X, Y]) []Pair[X, Y] { return append(a, b...) },
+		Finisher: func(acc []Pair[X, Y]) Optional[float64] {
+			xs := make([]float64, len(acc))
+			ys := make([]float64, len(acc))
+			for i, p := range acc {
+				xs[i], ys[i] = float64(p.First), float64(p.Second)
+			}
+			rx, ry := ranks(xs), ranks(ys)
+			var b bivariateState
+			for i := range rx {
+				b.add(rx[i], ry[i])
+			}
+			return PearsonCorrelationCollector[float64, float64]().Finisher(&b)
+		},
+	}
+}
+
+// pairsBy maps each element to an (x, y) pair using two extractors.
+func pairsBy[T any, X, Y Numeric](s Stream[T], fx func(T) X, fy func(T) Y) Stream[Pair[X, Y]] {
+	return MapTo(s, func(v T) Pair[X, Y] { return NewPair(fx(v), fy(v)) })
+}
+
+// Covariance returns the sample covariance of two variables extracted from each element.
+// Returns None for fewer than two elements.
+func Covariance[T any, X, Y Numeric](s Stream[T], fx func(T) X, fy func(T) Y) Optional[float64] {
+	return CollectTo(pairsBy(s, fx, fy), CovarianceCollector[X, Y]())
+}
+
+// PearsonCorrelation returns the Pearson correlation of two variables extracted from each element.
+// Returns None for fewer than two elements or if either variable is constant.
+func PearsonCorrelation[T any, X, Y Numeric](s Stream[T], fx func(T) X, fy func(T) Y) Optional[float64] {
+	return CollectTo(pairsBy(s, fx, fy), PearsonCorrelationCollector[X, Y]())
+}
+
+// SpearmanCorrelation returns Spearman's rank correlation of two variables extracted from each element.
+// Returns None for fewer than two elements or if either variable is constant.
+func SpearmanCorrelation[T any, X, Y Numeric](s Stream[T], fx func(T) X, fy func(T) Y) Optional[float64] {
+	return CollectTo(pairsBy(s, fx, fy), SpearmanCorrelationCollector[X, Y]())
+}
+
+// FitLinear fits y = Slope*x + Intercept by least squares over two variables extracted from each element.
+// Returns None for fewer than two elements or if all x values are equal.
+func FitLinear[T any, X, Y Numeric](s Stream[T], fx func(T) X, fy func(T) Y) Optional[LinearFit] {
+	return CollectTo(pairsBy(s, fx, fy), LinearRegressionCollector[X, Y]())
+}
+
 // RunningSum returns a Stream of cumulative sums.
 func RunningSum[T Numeric](s Stream[T]) Stream[T] {
 	return Stream[T]{
@@ -293,6 +899,205 @@ func Differences[T Numeric](s Stream[T]) Stream[T] {
 	}
 }
 
+// --- Smoothing and Rolling Windows ---
+//
+// Rolling operators emit one value per element once the window is full, so the output
+// has window-1 fewer elements than the input. Each step is amortized O(1).
+
+// MovingAverage returns a Stream of simple moving averages over the last window elements.
+// Returns an empty stream if window <= 0.
+func MovingAverage[T Numeric](s Stream[T], window int) Stream[float64] {
+	if window <= 0 {
+		return Empty[float64]()
+	}
+	return Stream[float64]{
+		seq: func(yield func(float64) bool) {
+			buf := make([]float64, window)
+			var sum float64
+			n, pos := 0, 0
+			for v := range s.seq {
+				x := float64(v)
+				sum += x - buf[pos]
+				buf[pos] = x
+				pos = (pos + 1) % window
+				n = min(n+1, window)
+				if n < window {
+					continue
+				}
+				if pos == 0 {
+					// Recompute once per window so rounding errors cannot accumulate
+					sum = 0
+					for _, b := range buf {
+						sum += b
+					}
+				}
+				if !yield(sum / float64(window)) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// EWMA returns a Stream of exponentially weighted moving averages with smoothing factor alpha:
+// avg = alpha*x + (1-alpha)*avg, seeded with the first element.
+// Returns an empty stream if alpha is not in (0, 1].
+func EWMA[T Numeric](s Stream[T], alpha float64) Stream[float64] {
+	if !(alpha > 0 && alpha <= 1) {
+		return Empty[float64]()
+	}
+	return Stream[float64]{
+		seq: func(yield func(float64) bool) {
+			var avg float64
+			first := true
+			for v := range s.seq {
+				if first {
+					avg = float64(v)
+					first = false
+				} else {
+					avg += alpha * (float64(v) - avg)
+				}
+				if !yield(avg) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// EWMAHalfLife returns a Stream of exponentially weighted moving averages where the weight of
+// an element halves every halfLife elements. Returns an empty stream if halfLife <= 0.
+func EWMAHalfLife[T Numeric](s Stream[T], halfLife float64) Stream[float64] {
+	if !(halfLife > 0) {
+		return Empty[float64]()
+	}
+	return EWMA(s, 1-math.Exp(-math.Ln2/halfLife))
+}
+
+// TimeWeightedEWMA returns a Stream of exponentially weighted moving averages for irregularly
+// spaced values: the previous average's weight halves every halfLife of elapsed time between
+// timestamps. Each result carries the timestamp of its input. Out-of-order timestamps are
+// treated as no elapsed time. Returns an empty stream if halfLife <= 0.
+func TimeWeightedEWMA[T Numeric](s Stream[TimestampedValue[T]], halfLife time.Duration) Stream[TimestampedValue[float64]] {
+	if halfLife <= 0 {
+		return Empty[TimestampedValue[float64]]()
+	}
+	return Stream[TimestampedValue[float64]]{
+		seq: func(yield func(TimestampedValue[float64]) bool) {
+			var avg float64
+			var last time.Time
+			first := true
+			for tv := range s.seq {
+				x := float64(tv.Value)
+				if first {
+					avg = x
+					first = false
+				} else {
+					dt := max(tv.Timestamp.Sub(last), 0)
+					alpha := 1 - math.Exp(-math.Ln2*float64(dt)/float64(halfLife))
+					avg += alpha * (x - avg)
+				}
+				last = tv.Timestamp
+				if !yield(NewTimestampedAt(avg, tv.Timestamp)) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// rollingExtreme emits the best element of each window using a monotonic deque.
+// better(a, b) reports whether a should replace b as the window's extreme.
+func rollingExtreme[T any](s Stream[T], window int, better func(a, b T) bool) Stream[T] {
+	if window <= 0 {
+		return Empty[T]()
+	}
+	type entry struct {
+		index int
+		value T
+	}
+	return Stream[T]{
+		seq: func(yield func(T) bool) {
+			// Deque values are in best-first order; each element is pushed and popped at most once
+			var deque []entry
+			i := 0
+			for v := range s.seq {
+				for len(deque) > 0 && !better(deque[len(deque)-1].value, v) {
+					deque = deque[:len(deque)-1]
+				}
+				deque = append(deque, entry{index: i, value: v})
+				if deque[0].index <= i-window {
+					deque = deque[1:]
+				}
+				i++
+				if i >= window && !yield(deque[0].value) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// RollingMin returns a Stream of minimums over the last window elements.
+// Returns an empty stream if window <= 0.
+func RollingMin[T cmp.Ordered](s Stream[T], window int) Stream[T] {
+	return rollingExtreme(s, window, func(a, b T) bool { return a < b })
+}
+
+// RollingMax returns a Stream of maximums over the last window elements.
+// Returns an empty stream if window <= 0.
+func RollingMax[T cmp.Ordered](s Stream[T], window int) Stream[T] {
+	return rollingExtreme(s, window, func(a, b T) bool { return a > b })
+}
+
+// RollingStdDev returns a Stream of sample standard deviations over the last window elements.
+// Returns an empty stream if window < 2.
+func RollingStdDev[T Numeric](s Stream[T], window int) Stream[float64] {
+	if window < 2 {
+		return Empty[float64]()
+	}
+	return Stream[float64]{
+		seq: func(yield func(float64) bool) {
+			buf := make([]float64, window)
+			var mean, m2 float64
+			n, pos := 0, 0
+			for v := range s.seq {
+				x := float64(v)
+				if n < window {
+					// Welford insertion while the window fills
+					n++
+					delta := x - mean
+					mean += delta / float64(n)
+					m2 += delta * (x - mean)
+				} else {
+					// Replace the oldest value, keeping n fixed
+					old := buf[pos]
+					prevMean := mean
+					mean += (x - old) / float64(n)
+					m2 += (x - old) * (x - mean + old - prevMean)
+				}
+				buf[pos] = x
+				pos = (pos + 1) % window
+				if n < window {
+					continue
+				}
+				if pos == 0 {
+					// Recompute once per window so rounding errors cannot accumulate
+					mean, m2 = 0, 0
+					for k, b := range buf {
+						delta := b - mean
+						mean += delta / float64(k+1)
+						m2 += delta * (b - mean)
+					}
+				}
+				if !yield(math.Sqrt(max(m2, 0) / float64(n-1))) {
+					return
+				}
+			}
+		},
+	}
+}
+
 // Clamp returns a Stream where each element is clamped to [min, max].
 func Clamp[T cmp.Ordered](s Stream[T], minVal, maxVal T) Stream[T] {
 	return Stream[T]{
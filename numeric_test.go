@@ -1,9 +1,13 @@
 package streams
 
 import (
+	"math"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestNumericOperations tests numeric stream functions.
@@ -330,3 +334,343 @@ func TestNumericOperations(t *testing.T) {
 		assert.Equal(t, "A", result.Get().Name, "MaxBy should return the largest key element")
 	})
 }
+
+func TestDescriptiveStatistics(t *testing.T) {
+	t.Parallel()
+	t.Run("KnownValues", func(t *testing.T) {
+		t.Parallel()
+		result := GetDescriptiveStatistics(Of(2, 4, 4, 4, 5, 5, 7, 9))
+		require.True(t, result.IsPresent(), "GetDescriptiveStatistics should return Some for non-empty")
+
+		stats := result.Get()
+		assert.Equal(t, 8, stats.Count, "Count should match")
+		assert.Equal(t, 40.0, stats.Sum, "Sum should match")
+		assert.Equal(t, 2.0, stats.Min, "Min should match")
+		assert.Equal(t, 9.0, stats.Max, "Max should match")
+		assert.InDelta(t, 5.0, stats.Mean, 1e-12, "Mean should match")
+		assert.InDelta(t, 4.0, stats.PopulationVariance, 1e-12, "Population variance should match")
+		assert.InDelta(t, 32.0/7, stats.SampleVariance, 1e-12, "Sample variance should match")
+		assert.InDelta(t, 2.0, stats.PopulationStdDev, 1e-12, "Population stddev should match")
+		assert.InDelta(t, math.Sqrt(32.0/7), stats.SampleStdDev, 1e-12, "Sample stddev should match")
+		assert.InDelta(t, 0.65625, stats.Skewness, 1e-12, "Skewness should match")
+		assert.InDelta(t, -0.21875, stats.Kurtosis, 1e-12, "Excess kurtosis should match")
+	})
+
+	t.Run("EmptyAndSingle", func(t *testing.T) {
+		t.Parallel()
+		assert.True(t, GetDescriptiveStatistics(Empty[float64]()).IsEmpty(), "Empty stream should be None")
+
+		stats := GetDescriptiveStatistics(Of(3.5)).Get()
+		assert.Equal(t, 3.5, stats.Mean, "Single value mean should be the value")
+		assert.Equal(t, 0.0, stats.PopulationVariance, "Single value population variance should be 0")
+		assert.True(t, math.IsNaN(stats.SampleVariance), "Single value sample variance should be NaN")
+		assert.True(t, math.IsNaN(stats.Skewness), "Single value skewness should be NaN")
+	})
+
+	t.Run("NumericallyStable", func(t *testing.T) {
+		t.Parallel()
+		// Large offset makes the naive sum-of-squares formula lose all precision
+		s := Of(1e9+4, 1e9+7, 1e9+13, 1e9+16)
+		stats := GetDescriptiveStatistics(s).Get()
+		assert.InDelta(t, 1e9+10, stats.Mean, 1e-6, "Mean should be exact")
+		assert.InDelta(t, 30.0, stats.SampleVariance, 1e-6, "Variance should survive a large offset")
+	})
+
+	t.Run("MergeMatchesSequential", func(t *testing.T) {
+		t.Parallel()
+		values := MapTo(Range(0, 10_000), func(i int) float64 { return math.Sin(float64(i)) * float64(i%97) })
+		seq := GetDescriptiveStatistics(values).Get()
+		par := ParallelCollectTo(values, DescriptiveStatisticsCollector[float64](), WithConcurrency(4)).Get()
+
+		assert.Equal(t, seq.Count, par.Count, "Count should match")
+		assert.Equal(t, seq.Min, par.Min, "Min should match")
+		assert.Equal(t, seq.Max, par.Max, "Max should match")
+		assert.InDelta(t, seq.Mean, par.Mean, 1e-9, "Mean should match")
+		assert.InEpsilon(t, seq.SampleVariance, par.SampleVariance, 1e-9, "Variance should match")
+		assert.InDelta(t, seq.Skewness, par.Skewness, 1e-9, "Skewness should match")
+		assert.InDelta(t, seq.Kurtosis, par.Kurtosis, 1e-9, "Kurtosis should match")
+	})
+
+	t.Run("MergeWithEmpty", func(t *testing.T) {
+		t.Parallel()
+		c := DescriptiveStatisticsCollector[int]()
+		a, b := c.Supplier(), c.Supplier()
+		a = c.Accumulator(a, 1)
+		a = c.Combiner(a, b)
+		b = c.Combiner(b, a)
+		assert.Equal(t, 1.0, c.Finisher(b).Get().Mean, "Merging into empty state should copy moments")
+	})
+}
+
+func TestBivariateStatistics(t *testing.T) {
+	t.Parallel()
+	type sample struct {
+		Hours int
+		Score float64
+	}
+	samples := []sample{{1, 52}, {2, 55}, {3, 61}, {4, 64}, {5, 68}}
+
+	t.Run("Covariance", func(t *testing.T) {
+		t.Parallel()
+		result := CollectTo(Of(NewPair(1, 2.0), NewPair(2, 4.0), NewPair(3, 6.5)), CovarianceCollector[int, float64]())
+		assert.InDelta(t, 2.25, result.Get(), 1e-12, "Sample covariance should match")
+
+		byFields := Covariance(FromSlice(samples), func(s sample) int { return s.Hours }, func(s sample) float64 { return s.Score })
+		assert.InDelta(t, 10.25, byFields.Get(), 1e-12, "Covariance with extractors should match")
+		assert.True(t, Covariance(Of(1), func(n int) int { return n }, func(n int) int { return n }).IsEmpty(), "Covariance of one element should be None")
+	})
+
+	t.Run("PearsonCorrelation", func(t *testing.T) {
+		t.Parallel()
+		perfect := PearsonCorrelation(Range(0, 10), func(n int) int { return n }, func(n int) int { return 3*n + 1 })
+		assert.InDelta(t, 1.0, perfect.Get(), 1e-12, "Linear relation should have correlation 1")
+		negative := PearsonCorrelation(Range(0, 10), func(n int) int { return n }, func(n int) int { return -n })
+		assert.InDelta(t, -1.0, negative.Get(), 1e-12, "Inverse relation should have correlation -1")
+
+		r := PearsonCorrelation(FromSlice(samples), func(s sample) int { return s.Hours }, func(s sample) float64 { return s.Score })
+		assert.InDelta(t, 0.99440, r.Get(), 1e-5, "Pearson correlation should match")
+
+		constant := PearsonCorrelation(Range(0, 5), func(n int) int { return n }, func(int) int { return 7 })
+		assert.True(t, constant.IsEmpty(), "Correlation with a constant variable should be None")
+	})
+
+	t.Run("SpearmanCorrelation", func(t *testing.T) {
+		t.Parallel()
+		monotone := SpearmanCorrelation(Range(1, 20), func(n int) int { return n }, func(n int) float64 { return math.Exp(float64(n)) })
+		assert.InDelta(t, 1.0, monotone.Get(), 1e-12, "Monotone relation should have rank correlation 1")
+
+		ties := CollectTo(Of(NewPair(1, 1), NewPair(2, 2), NewPair(2, 3), NewPair(3, 3)), SpearmanCorrelationCollector[int, int]())
+		assert.InDelta(t, 5.0/6, ties.Get(), 1e-12, "Ties should use average ranks")
+
+		assert.True(t, SpearmanCorrelation(Empty[int](), func(n int) int { return n }, func(n int) int { return n }).IsEmpty(), "Empty stream should be None")
+	})
+
+	t.Run("LinearRegression", func(t *testing.T) {
+		t.Parallel()
+		fit := FitLinear(FromSlice(samples), func(s sample) int { return s.Hours }, func(s sample) float64 { return s.Score }).Get()
+		assert.Equal(t, 5, fit.Count, "Fit should count pairs")
+		assert.InDelta(t, 4.1, fit.Slope, 1e-12, "Slope should match")
+		assert.InDelta(t, 47.7, fit.Intercept, 1e-12, "Intercept should match")
+		assert.InDelta(t, 0.98882, fit.RSquared, 1e-5, "R-squared should match")
+		assert.InDelta(t, 72.3, fit.Predict(6), 1e-9, "Predict should apply the fitted line")
+
+		flat := CollectTo(Of(NewPair(1, 5), NewPair(2, 5)), LinearRegressionCollector[int, int]()).Get()
+		assert.Equal(t, 0.0, flat.Slope, "Constant y should have zero slope")
+		assert.Equal(t, 1.0, flat.RSquared, "Constant y should be a perfect fit")
+
+		vertical := CollectTo(Of(NewPair(1, 1), NewPair(1, 2)), LinearRegressionCollector[int, int]())
+		assert.True(t, vertical.IsEmpty(), "Constant x should have no fit")
+	})
+
+	t.Run("ParallelMatchesSequential", func(t *testing.T) {
+		t.Parallel()
+		pairs := MapTo(Range(0, 10_000), func(i int) Pair[float64, float64] {
+			x := float64(i)
+			return NewPair(x, 2*x+10*math.Sin(x))
+		})
+		seq := CollectTo(pairs, LinearRegressionCollector[float64, float64]()).Get()
+		par := ParallelCollectTo(pairs, LinearRegressionCollector[float64, float64](), WithConcurrency(4)).Get()
+		assert.Equal(t, seq.Count, par.Count, "Count should match")
+		assert.InDelta(t, seq.Slope, par.Slope, 1e-9, "Slope should match")
+		assert.InDelta(t, seq.Intercept, par.Intercept, 1e-6, "Intercept should match")
+		assert.InDelta(t, seq.RSquared, par.RSquared, 1e-9, "R-squared should match")
+
+		rho := ParallelCollectTo(pairs, SpearmanCorrelationCollector[float64, float64](), WithConcurrency(4))
+		assert.InDelta(t, CollectTo(pairs, SpearmanCorrelationCollector[float64, float64]()).Get(), rho.Get(), 1e-12, "Spearman should match")
+	})
+}
+
+func TestSmoothing(t *testing.T) {
+	t.Parallel()
+	t.Run("MovingAverage", func(t *testing.T) {
+		t.Parallel()
+		result := MovingAverage(Of(1, 2, 3, 4, 5, 6), 3).Collect()
+		assert.Equal(t, []float64{2, 3, 4, 5}, result, "MovingAverage should average each full window")
+		assert.Empty(t, MovingAverage(Of(1, 2), 3).Collect(), "Fewer elements than the window should emit nothing")
+		assert.Empty(t, MovingAverage(Of(1, 2), 0).Collect(), "Non-positive window should be empty")
+
+		long := MovingAverage(MapTo(Range(0, 10_000), func(i int) float64 { return 1e8 + float64(i%2)*0.1 }), 2).Collect()
+		assert.InDelta(t, 1e8+0.05, long[len(long)-1], 1e-7, "MovingAverage should not drift over long streams")
+	})
+
+	t.Run("EWMA", func(t *testing.T) {
+		t.Parallel()
+		result := EWMA(Of(10, 20, 20), 0.5).Collect()
+		assert.Equal(t, []float64{10, 15, 17.5}, result, "EWMA should be seeded with the first element")
+		assert.Empty(t, EWMA(Of(1), 0).Collect(), "Invalid alpha should be empty")
+		assert.Empty(t, EWMA(Of(1), 1.5).Collect(), "Invalid alpha should be empty")
+
+		halfLife := EWMAHalfLife(Of(0, 1, 1), 1).Collect()
+		assert.InDeltaSlice(t, []float64{0, 0.5, 0.75}, halfLife, 1e-12, "Half-life of one element should give alpha 0.5")
+		assert.Empty(t, EWMAHalfLife(Of(1), -1).Collect(), "Non-positive half-life should be empty")
+	})
+
+	t.Run("TimeWeightedEWMA", func(t *testing.T) {
+		t.Parallel()
+		base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		values := Of(
+			NewTimestampedAt(0.0, base),
+			NewTimestampedAt(1.0, base.Add(time.Minute)),
+			NewTimestampedAt(1.0, base.Add(time.Minute)),
+			NewTimestampedAt(1.0, base.Add(3*time.Minute)),
+		)
+		result := TimeWeightedEWMA(values, time.Minute).Collect()
+		require.Len(t, result, 4, "TimeWeightedEWMA should emit one value per input")
+		assert.InDelta(t, 0.5, result[1].Value, 1e-12, "One half-life should halve the old weight")
+		assert.InDelta(t, 0.5, result[2].Value, 1e-12, "No elapsed time should keep the average")
+		assert.InDelta(t, 0.875, result[3].Value, 1e-12, "Two half-lives should quarter the old weight")
+		assert.Equal(t, base.Add(3*time.Minute), result[3].Timestamp, "Results should keep input timestamps")
+		assert.Empty(t, TimeWeightedEWMA(values, 0).Collect(), "Non-positive half-life should be empty")
+	})
+
+	t.Run("RollingMinMax", func(t *testing.T) {
+		t.Parallel()
+		data := []int{4, 2, 12, 3, 8, 8, 1, 5}
+		assert.Equal(t, []int{2, 2, 3, 3, 1, 1}, RollingMin(FromSlice(data), 3).Collect(), "RollingMin should track window minimums")
+		assert.Equal(t, []int{12, 12, 12, 8, 8, 8}, RollingMax(FromSlice(data), 3).Collect(), "RollingMax should track window maximums")
+		assert.Equal(t, data, RollingMax(FromSlice(data), 1).Collect(), "Window of one should be the identity")
+		assert.Empty(t, RollingMin(FromSlice(data), 0).Collect(), "Non-positive window should be empty")
+		assert.Equal(t, []string{"b", "c"}, RollingMax(Of("a", "b", "c"), 2).Collect(), "Rolling operators should work on any ordered type")
+	})
+
+	t.Run("RollingMinMaxMatchesBruteForce", func(t *testing.T) {
+		t.Parallel()
+		rng := newTestRand(11)
+		data := make([]int, 500)
+		for i := range data {
+			data[i] = rng.IntN(50)
+		}
+		const w = 7
+		mins := RollingMin(FromSlice(data), w).Collect()
+		maxs := RollingMax(FromSlice(data), w).Collect()
+		for i := range mins {
+			assert.Equal(t, slices.Min(data[i:i+w]), mins[i], "RollingMin mismatch at %d", i)
+			assert.Equal(t, slices.Max(data[i:i+w]), maxs[i], "RollingMax mismatch at %d", i)
+		}
+	})
+
+	t.Run("RollingStdDev", func(t *testing.T) {
+		t.Parallel()
+		data := []float64{2, 4, 4, 4, 5, 5, 7, 9, 1e9, 1e9 + 1, 1e9 + 2}
+		result := RollingStdDev(FromSlice(data), 4).Collect()
+		require.Len(t, result, len(data)-3, "RollingStdDev should emit once the window is full")
+		for i, got := range result {
+			want := GetDescriptiveStatistics(FromSlice(data[i : i+4])).Get().SampleStdDev
+			assert.InDelta(t, want, got, 1e-6, "RollingStdDev mismatch at %d", i)
+		}
+		assert.Empty(t, RollingStdDev(Of(1, 2), 1).Collect(), "Window below two should be empty")
+	})
+}
+
+func TestBinnedHistogram(t *testing.T) {
+	t.Parallel()
+	t.Run("BucketHelpers", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, []float64{0, 10, 20}, LinearBuckets(0, 10, 3), "LinearBuckets should step by width")
+		assert.Equal(t, []float64{1, 2, 4, 8}, ExponentialBuckets(1, 2, 4), "ExponentialBuckets should multiply by factor")
+		assert.Nil(t, LinearBuckets(0, 0, 3), "Non-positive width should return nil")
+		assert.Nil(t, ExponentialBuckets(1, 1, 3), "Factor <= 1 should return nil")
+	})
+
+	t.Run("Counts", func(t *testing.T) {
+		t.Parallel()
+		h := CollectTo(Of(0.5, 1, 1.5, 3, 7, 100), BinnedHistogramCollector[float64]([]float64{5, 1, 2, 1, math.NaN()}))
+		assert.Equal(t, []HistogramBucket{
+			{UpperBound: 1, Count: 2},
+			{UpperBound: 2, Count: 1},
+			{UpperBound: 5, Count: 1},
+			{UpperBound: math.Inf(1), Count: 2},
+		}, h.Buckets(), "Values should fall into upper-inclusive buckets")
+		assert.Equal(t, int64(6), h.Count(), "Count should include all values")
+		assert.Equal(t, 113.0, h.Sum(), "Sum should include all values")
+		assert.Equal(t, 0.5, h.Min().Get(), "Min should be tracked")
+		assert.Equal(t, 100.0, h.Max().Get(), "Max should be tracked")
+	})
+
+	t.Run("Quantile", func(t *testing.T) {
+		t.Parallel()
+		h := CollectTo(Range(1, 1001), BinnedHistogramCollector[int](LinearBuckets(100, 100, 10)))
+		assert.InDelta(t, 500, h.Quantile(0.5).Get(), 1, "Median should interpolate within its bucket")
+		assert.InDelta(t, 990, h.Quantile(0.99).Get(), 1, "p99 should interpolate within its bucket")
+		assert.Equal(t, 1.0, h.Quantile(0).Get(), "Quantile 0 should be the min")
+		assert.Equal(t, 1000.0, h.Quantile(1).Get(), "Quantile 1 should be the max")
+
+		overflow := CollectTo(Of(1, 50, 100), BinnedHistogramCollector[int]([]float64{10}))
+		assert.InDelta(t, 100, overflow.Quantile(1).Get(), 1e-9, "Overflow bucket should be bounded by max")
+
+		empty := NewBinnedHistogram(ExponentialBuckets(1, 2, 5))
+		assert.True(t, empty.Quantile(0.5).IsEmpty(), "Empty histogram quantile should be None")
+		assert.True(t, empty.Min().IsEmpty(), "Empty histogram Min should be None")
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		t.Parallel()
+		bounds := ExponentialBuckets(1, 10, 4)
+		seq := CollectTo(Range(0, 20_000), BinnedHistogramCollector[int](bounds))
+		par := ParallelCollectTo(Range(0, 20_000), BinnedHistogramCollector[int](bounds), WithConcurrency(4))
+		assert.Equal(t, seq.Buckets(), par.Buckets(), "Parallel histogram should match sequential")
+		assert.Equal(t, seq.Sum(), par.Sum(), "Parallel sum should match sequential")
+
+		assert.ErrorIs(t, seq.Merge(NewBinnedHistogram(LinearBuckets(0, 1, 3))), ErrInvalidSketch, "Merge with different bounds should fail")
+		assert.NoError(t, seq.Merge(nil), "Merge with nil should be a no-op")
+	})
+}
+
+func TestCompensatedAndCheckedSums(t *testing.T) {
+	t.Parallel()
+	t.Run("SumKahan", func(t *testing.T) {
+		t.Parallel()
+		tenth := Generate(func() float64 { return 0.1 }).Limit(1_000_000)
+		assert.NotEqual(t, 100_000.0, Sum(tenth), "Naive sum should drift")
+		assert.Equal(t, 100_000.0, SumKahan(tenth), "Compensated sum should not drift")
+
+		// Neumaier handles terms larger than the running sum, where plain Kahan fails
+		assert.Equal(t, 2.0, SumKahan(Of(1.0, 1e100, 1.0, -1e100)), "SumKahan should recover small terms around large ones")
+		assert.Equal(t, float32(0), SumKahan(Empty[float32]()), "SumKahan of empty should be zero")
+	})
+
+	t.Run("SumChecked", func(t *testing.T) {
+		t.Parallel()
+		ok := SumChecked(Of(1, 2, 3))
+		assert.True(t, ok.IsOk(), "SumChecked without overflow should be Ok")
+		assert.Equal(t, 6, ok.Value(), "SumChecked should sum elements")
+
+		overflow := SumChecked(Of(int8(100), int8(27), int8(1)))
+		assert.True(t, overflow.IsErr(), "SumChecked should detect signed overflow")
+		assert.ErrorIs(t, overflow.Error(), ErrOverflow, "Overflow error should be ErrOverflow")
+
+		assert.True(t, SumChecked(Of(int8(100), int8(27))).IsOk(), "Sum at the maximum should not overflow")
+		assert.ErrorIs(t, SumChecked(Of(int8(-100), int8(-29))).Error(), ErrOverflow, "SumChecked should detect negative overflow")
+		assert.Equal(t, int8(-128), SumChecked(Of(int8(-100), int8(-28))).Value(), "Sum at the minimum should not overflow")
+		assert.Equal(t, int8(0), SumChecked(Of(int8(100), int8(27), int8(-127))).Value(), "Mixed signs should not overflow")
+
+		assert.ErrorIs(t, SumChecked(Of(uint8(200), uint8(56))).Error(), ErrOverflow, "SumChecked should detect unsigned overflow")
+		assert.Equal(t, uint8(255), SumChecked(Of(uint8(200), uint8(55))).Value(), "Unsigned sum at the maximum should not overflow")
+		assert.ErrorIs(t, SumChecked(Of(math.MaxInt64, 1)).Error(), ErrOverflow, "SumChecked should detect int64 overflow")
+	})
+
+	t.Run("SumCheckedStopsEarly", func(t *testing.T) {
+		t.Parallel()
+		consumed := 0
+		s := Iterate(int8(100), func(n int8) int8 { return n }).Peek(func(int8) { consumed++ })
+		assert.True(t, SumChecked(s).IsErr(), "SumChecked should fail on infinite overflowing stream")
+		assert.Equal(t, 2, consumed, "SumChecked should stop at the first overflow")
+	})
+
+	t.Run("GetStatisticsCompensated", func(t *testing.T) {
+		t.Parallel()
+		tenth := Generate(func() float64 { return 0.1 }).Limit(1_000_000)
+		naive := GetStatistics(tenth).Get()
+		compensated := GetStatistics(tenth, WithCompensatedSum()).Get()
+		assert.NotEqual(t, 100_000.0, naive.Sum, "Naive statistics sum should drift")
+		assert.Equal(t, 100_000.0, compensated.Sum, "Compensated statistics sum should not drift")
+		assert.Equal(t, 0.1, compensated.Average, "Compensated average should be exact")
+		assert.Equal(t, 1_000_000, compensated.Count, "Count should be unchanged")
+
+		ints := GetStatistics(Of(math.MaxInt64-1, 1), WithCompensatedSum()).Get()
+		assert.Equal(t, math.MaxInt64, ints.Sum, "Integer sums should stay exact with compensation enabled")
+
+		desc := GetDescriptiveStatistics(tenth).Get()
+		assert.Equal(t, 100_000.0, desc.Sum, "DescriptiveStatistics sum should be compensated")
+	})
+}
@@ -1078,6 +1078,91 @@ func parallelFlatMapUnordered[T, U any](ctx context.Context, s Stream[T], fn fun
 	}
 }
 
+// --- Parallel Join ---
+
+// ParallelInnerJoin performs an inner join between two Stream2s, probing the left stream in parallel.
+// By default, it preserves the order of the left stream.
+// Note: The second stream is collected into memory for the join.
+func ParallelInnerJoin[K comparable, V1, V2 any](s1 Stream2[K, V1], s2 Stream2[K, V2], opts ...ParallelOption) Stream[JoinResult[K, V1, V2]] {
+	return ParallelInnerJoinCtx(context.Background(), s1, s2, opts...)
+}
+
+// ParallelInnerJoinCtx performs an inner join between two Stream2s, probing the left stream
+// in parallel with context support.
+// The right stream is collected once into a lookup map that is shared read-only by all workers.
+func ParallelInnerJoinCtx[K comparable, V1, V2 any](ctx context.Context, s1 Stream2[K, V1], s2 Stream2[K, V2], opts ...ParallelOption) Stream[JoinResult[K, V1, V2]] {
+	return Stream[JoinResult[K, V1, V2]]{
+		seq: func(yield func(JoinResult[K, V1, V2]) bool) {
+			// Build lookup map from s2 before any worker starts
+			lookup := make(map[K][]V2)
+			for k, v := range s2.seq {
+				lookup[k] = append(lookup[k], v)
+			}
+
+			probed := ParallelFlatMapCtx(ctx, s1.ToPairs(), func(_ context.Context, p Pair[K, V1]) Stream[JoinResult[K, V1, V2]] {
+				v2s := lookup[p.First]
+				return Stream[JoinResult[K, V1, V2]]{
+					seq: func(yield func(JoinResult[K, V1, V2]) bool) {
+						for _, v2 := range v2s {
+							if !yield(JoinResult[K, V1, V2]{Key: p.First, Left: p.Second, Right: v2}) {
+								return
+							}
+						}
+					},
+				}
+			}, opts...)
+
+			for r := range probed.seq {
+				if !yield(r) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// ParallelJoinBy performs an inner join on two streams using key extraction functions,
+// probing the first stream in parallel.
+// By default, it preserves the order of the first stream.
+func ParallelJoinBy[T, U any, K comparable](s1 Stream[T], s2 Stream[U], keyT func(T) K, keyU func(U) K, opts ...ParallelOption) Stream[Pair[T, U]] {
+	return ParallelJoinByCtx(context.Background(), s1, s2, keyT, keyU, opts...)
+}
+
+// ParallelJoinByCtx performs an inner join on two streams using key extraction functions,
+// probing the first stream in parallel with context support.
+// The second stream is collected once into a lookup map that is shared read-only by all workers.
+func ParallelJoinByCtx[T, U any, K comparable](ctx context.Context, s1 Stream[T], s2 Stream[U], keyT func(T) K, keyU func(U) K, opts ...ParallelOption) Stream[Pair[T, U]] {
+	return Stream[Pair[T, U]]{
+		seq: func(yield func(Pair[T, U]) bool) {
+			// Build lookup from s2 before any worker starts
+			lookup := make(map[K][]U)
+			for u := range s2.seq {
+				k := keyU(u)
+				lookup[k] = append(lookup[k], u)
+			}
+
+			probed := ParallelFlatMapCtx(ctx, s1, func(_ context.Context, t T) Stream[Pair[T, U]] {
+				us := lookup[keyT(t)]
+				return Stream[Pair[T, U]]{
+					seq: func(yield func(Pair[T, U]) bool) {
+						for _, u := range us {
+							if !yield(Pair[T, U]{First: t, Second: u}) {
+								return
+							}
+						}
+					},
+				}
+			}, opts...)
+
+			for p := range probed.seq {
+				if !yield(p) {
+					return
+				}
+			}
+		},
+	}
+}
+
 // --- Prefetch ---
 
 // Prefetch creates a Stream that prefetches n elements ahead in a goroutine.
@@ -1250,6 +1335,67 @@ func ParallelCollect[T any](s Stream[T], opts ...ParallelOption) []T {
 	return results
 }
 
+// ParallelCollectTo collects stream elements in parallel using the given Collector.
+// Each worker accumulates into its own accumulator; the partial accumulators are then
+// merged with the collector's Combiner, so the collector must not depend on element order.
+// If the collector has no Combiner, elements are collected sequentially with CollectTo.
+// A worker whose accumulator is done stops receiving elements, and the source stops
+// being pulled once every worker is done, so short-circuiting collectors such as
+// AnyMatchCollector terminate on infinite streams.
+func ParallelCollectTo[T, A, R any](s Stream[T], c Collector[T, A, R], opts ...ParallelOption) R {
+	if c.Combiner == nil {
+		return CollectTo(s, c)
+	}
+
+	cfg := DefaultParallelConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var (
+		inputCh  = make(chan T, cfg.BufferSize)
+		accs     = make([]A, cfg.Concurrency)
+		wg       sync.WaitGroup
+		finished atomic.Int32
+		stop     = make(chan struct{})
+	)
+
+	for i := range cfg.Concurrency {
+		wg.Go(func() {
+			acc := c.Supplier()
+			for !c.done(acc) {
+				v, ok := <-inputCh
+				if !ok {
+					break
+				}
+				acc = c.Accumulator(acc, v)
+			}
+			accs[i] = acc
+			// Stop pulling the source once every worker is done
+			if c.done(acc) && finished.Add(1) == int32(cfg.Concurrency) {
+				close(stop)
+			}
+		})
+	}
+
+feed:
+	for v := range s.seq {
+		select {
+		case inputCh <- v:
+		case <-stop:
+			break feed
+		}
+	}
+	close(inputCh)
+	wg.Wait()
+
+	acc := accs[0]
+	for _, other := range accs[1:] {
+		acc = c.Combiner(acc, other)
+	}
+	return c.Finisher(acc)
+}
+
 // ParallelForEachCtx executes an action on each element in parallel with context support.
 func ParallelForEachCtx[T any](ctx context.Context, s Stream[T], action func(context.Context, T), opts ...ParallelOption) error {
 	cfg := DefaultParallelConfig()
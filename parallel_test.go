@@ -1,6 +1,7 @@
 package streams
 
 import (
+	"cmp"
 	"context"
 	"runtime"
 	"sort"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // testCtx returns a background context for tests.
@@ -1072,3 +1074,184 @@ func TestParallelFlatMapCtx(t *testing.T) {
 		assert.Equal(t, []int{1, 10, 2, 20, 3, 30}, result, "ParallelFlatMapCtx chunked should preserve order")
 	})
 }
+
+// --- Parallel Join Tests ---
+
+func TestParallelInnerJoin(t *testing.T) {
+	t.Parallel()
+	t.Run("OrderedMatchesSequential", func(t *testing.T) {
+		t.Parallel()
+		left := make([]Pair[int, int], 0, 200)
+		for i := range 200 {
+			left = append(left, NewPair(i%20, i))
+		}
+		right := PairsOf(NewPair(1, "a"), NewPair(2, "b"), NewPair(2, "c"), NewPair(50, "z"))
+
+		expected := InnerJoin(PairsOf(left...), right).Collect()
+		result := ParallelInnerJoin(PairsOf(left...), right, WithConcurrency(4)).Collect()
+
+		assert.Equal(t, expected, result, "ParallelInnerJoin ordered should match InnerJoin")
+	})
+
+	t.Run("Unordered", func(t *testing.T) {
+		t.Parallel()
+		left := PairsOf(NewPair("a", 1), NewPair("b", 2), NewPair("c", 3))
+		right := PairsOf(NewPair("a", "x"), NewPair("c", "z"))
+
+		result := ParallelInnerJoin(left, right, WithOrdered(false)).Collect()
+
+		keys := make([]string, len(result))
+		for i, r := range result {
+			keys[i] = r.Key
+		}
+		sort.Strings(keys)
+		assert.Equal(t, []string{"a", "c"}, keys, "ParallelInnerJoin unordered should join matching keys")
+	})
+
+	t.Run("EarlyTermination", func(t *testing.T) {
+		t.Parallel()
+		left := PairsOf(NewPair("a", 1), NewPair("a", 2), NewPair("a", 3))
+		right := PairsOf(NewPair("a", "x"))
+
+		result := ParallelInnerJoin(left, right).Limit(1).Collect()
+		assert.Len(t, result, 1, "ParallelInnerJoin should respect Limit")
+	})
+}
+
+func TestParallelInnerJoinCtx(t *testing.T) {
+	t.Parallel()
+	t.Run("CancelledContext", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(testCtx())
+		cancel()
+
+		result := ParallelInnerJoinCtx(ctx, ZipWithIndex(Range(0, 1000)), ZipWithIndex(Range(0, 1000))).Collect()
+
+		assert.Less(t, len(result), 1000, "ParallelInnerJoinCtx should stop on cancelled context")
+	})
+}
+
+func TestParallelJoinBy(t *testing.T) {
+	t.Parallel()
+	type User struct {
+		ID   int
+		Name string
+	}
+	type Order struct {
+		UserID int
+		Amount int
+	}
+
+	t.Run("OrderedMatchesSequential", func(t *testing.T) {
+		t.Parallel()
+		us := MapTo(Range(0, 500), func(i int) User { return User{ID: i % 50, Name: "u"} }).Collect()
+		orders := []Order{{UserID: 1, Amount: 10}, {UserID: 1, Amount: 20}, {UserID: 7, Amount: 5}}
+
+		expected := JoinBy(FromSlice(us), FromSlice(orders),
+			func(u User) int { return u.ID }, func(o Order) int { return o.UserID }).Collect()
+		result := ParallelJoinBy(FromSlice(us), FromSlice(orders),
+			func(u User) int { return u.ID }, func(o Order) int { return o.UserID }, WithConcurrency(8)).Collect()
+
+		assert.Equal(t, expected, result, "ParallelJoinBy ordered should match JoinBy")
+	})
+
+	t.Run("BuildsLookupOnce", func(t *testing.T) {
+		t.Parallel()
+		var rightReads atomic.Int32
+		orders := Of(Order{UserID: 1, Amount: 10}, Order{UserID: 2, Amount: 20}).Peek(func(Order) {
+			rightReads.Add(1)
+		})
+
+		result := ParallelJoinBy(Of(User{ID: 1}, User{ID: 2}, User{ID: 3}), orders,
+			func(u User) int { return u.ID }, func(o Order) int { return o.UserID }, WithOrdered(false)).Collect()
+
+		assert.Len(t, result, 2, "ParallelJoinBy should join matching keys")
+		assert.Equal(t, int32(2), rightReads.Load(), "ParallelJoinBy should read the right stream once")
+	})
+
+	t.Run("ChunkedOrdered", func(t *testing.T) {
+		t.Parallel()
+		result := ParallelJoinBy(Of(3, 1, 2), Of(1, 2, 3),
+			func(v int) int { return v }, func(v int) int { return v }, WithChunkSize(2)).Collect()
+
+		assert.Equal(t, []Pair[int, int]{NewPair(3, 3), NewPair(1, 1), NewPair(2, 2)}, result,
+			"ParallelJoinBy chunked should preserve left order")
+	})
+}
+
+func TestParallelJoinByCtx(t *testing.T) {
+	t.Parallel()
+	t.Run("CancelledContext", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(testCtx())
+		cancel()
+
+		result := ParallelJoinByCtx(ctx, Range(0, 1000), Range(0, 1000),
+			func(v int) int { return v }, func(v int) int { return v }).Collect()
+
+		assert.Less(t, len(result), 1000, "ParallelJoinByCtx should stop on cancelled context")
+	})
+}
+
+func TestParallelCollectTo(t *testing.T) {
+	t.Parallel()
+	t.Run("WithCombiner", func(t *testing.T) {
+		t.Parallel()
+		c := Collector[int, *int, int]{
+			Supplier:    func() *int { return new(int) },
+			Accumulator: func(acc *int, v int) *int { *acc += v; return acc },
+			Finisher:    func(acc *int) int { return *acc },
+			Combiner:    func(a, b *int) *int { *a += *b; return a },
+		}
+		result := ParallelCollectTo(Range(1, 1001), c, WithConcurrency(4))
+		assert.Equal(t, 500500, result, "ParallelCollectTo should merge partial sums")
+	})
+
+	t.Run("WithoutCombinerFallsBack", func(t *testing.T) {
+		t.Parallel()
+		result := ParallelCollectTo(Range(1, 6), ToSliceCollector[int]())
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, result, "ParallelCollectTo without Combiner should collect sequentially")
+	})
+
+	t.Run("GroupedSummingMatchesSequential", func(t *testing.T) {
+		t.Parallel()
+		byMod := GroupingByWith(func(n int) int { return n % 7 }, SummingCollector[int]())
+		require.NotNil(t, byMod.Combiner, "GroupingByWith over SummingCollector should be mergeable")
+		sequential := CollectTo(Range(0, 10_000), byMod)
+		parallel := ParallelCollectTo(Range(0, 10_000), byMod, WithConcurrency(4))
+		assert.Equal(t, sequential, parallel, "Parallel grouped sums should match sequential")
+	})
+
+	t.Run("BuiltInCombiners", func(t *testing.T) {
+		t.Parallel()
+		less := func(a, b int) bool { return a < b }
+		stats := Teeing4Collector(
+			CountingCollector[int](),
+			MaxByCollector(cmp.Compare[int]),
+			TopKCollector(3, less),
+			CrossTabCollector(func(n int) int { return n % 2 }, func(n int) int { return n % 3 }),
+			func(n int, maxV Optional[int], top []int, tab PivotTable[int, int, int]) Quad[int, Optional[int], []int, PivotTable[int, int, int]] {
+				return NewQuad(n, maxV, top, tab)
+			},
+		)
+		require.NotNil(t, stats.Combiner, "Teeing over mergeable collectors should be mergeable")
+		sequential := CollectTo(Range(0, 1000), stats)
+		parallel := ParallelCollectTo(Range(0, 1000), stats, WithConcurrency(4))
+		assert.Equal(t, sequential, parallel, "Parallel results should match sequential")
+	})
+
+	t.Run("ShortCircuitOnInfiniteStream", func(t *testing.T) {
+		t.Parallel()
+		var pulled atomic.Int64
+		naturals := Iterate(0, func(n int) int { return n + 1 }).Peek(func(int) { pulled.Add(1) })
+		result := ParallelCollectTo(naturals, AnyMatchCollector(func(n int) bool { return n > 100 }), WithConcurrency(4), WithBufferSize(8))
+		assert.True(t, result, "ParallelCollectTo should finish once every worker is done")
+		assert.Less(t, pulled.Load(), int64(100_000), "ParallelCollectTo should stop pulling the source")
+	})
+
+	t.Run("EmptyStream", func(t *testing.T) {
+		t.Parallel()
+		result := ParallelCollectTo(Empty[float64](), ApproxQuantileCollector[float64](100, 0.5), WithConcurrency(3))
+		assert.True(t, result.IsEmpty(), "ParallelCollectTo on empty stream should use empty accumulators")
+	})
+}
@@ -0,0 +1,144 @@
+package streams
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// --- Pivot Tables ---
+
+// PivotTable is the result of PivotCollector: a two-dimensional grid of aggregated cells.
+// RowKeys and ColKeys are sorted ascending; Cells holds only the (row, col) pairs that
+// received at least one element.
+type PivotTable[RK, CK cmp.Ordered, R any] struct {
+	RowKeys []RK
+	ColKeys []CK
+	Cells   map[RK]map[CK]R
+}
+
+// Get returns the cell at (row, col) and whether it exists.
+func (p PivotTable[RK, CK, R]) Get(row RK, col CK) (R, bool) {
+	v, ok := p.Cells[row][col]
+	return v, ok
+}
+
+// Cell returns the cell at (row, col) as an Optional.
+func (p PivotTable[RK, CK, R]) Cell(row RK, col CK) Optional[R] {
+	v, ok := p.Get(row, col)
+	return OptionalFromCondition(ok, v)
+}
+
+// Records returns the table as a stream of records ready for ToCSV.
+// The first record is a header made of corner followed by the column keys; each following
+// record is a row key followed by its cells. Keys are formatted with fmt.Sprint, cells with
+// format (fmt.Sprint if nil). Missing cells are empty strings.
+func (p PivotTable[RK, CK, R]) Records(corner string, format func(R) string) Stream[[]string] {
+	if format == nil {
+		format = func(v R) string { return fmt.Sprint(v) }
+	}
+	return Stream[[]string]{
+		seq: func(yield func([]string) bool) {
+			header := make([]string, 0, len(p.ColKeys)+1)
+			header = append(header, corner)
+			for _, c := range p.ColKeys {
+				header = append(header, fmt.Sprint(c))
+			}
+			if !yield(header) {
+				return
+			}
+			for _, r := range p.RowKeys {
+				record := make([]string, 0, len(p.ColKeys)+1)
+				record = append(record, fmt.Sprint(r))
+				row := p.Cells[r]
+				for _, c := range p.ColKeys {
+					if v, ok := row[c]; ok {
+						record = append(record, format(v))
+					} else {
+						record = append(record, "")
+					}
+				}
+				if !yield(record) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// PivotCollector returns a Collector that groups elements by a row key and a column key
+// and collects each cell with the downstream collector. Only one downstream accumulator
+// is kept per cell. The result is mergeable if the downstream has a Combiner.
+func PivotCollector[T any, RK, CK cmp.Ordered, A, R any](
+	rowKey func(T) RK,
+	colKey func(T) CK,
+	downstream Collector[T, A, R],
+) Collector[T, map[RK]map[CK]A, PivotTable[RK, CK, R]] {
+	c := Collector[T, map[RK]map[CK]A, PivotTable[RK, CK, R]]{
+		Supplier: func() map[RK]map[CK]A { return make(map[RK]map[CK]A) },
+		Accumulator: func(m map[RK]map[CK]A, v T) map[RK]map[CK]A {
+			rk, ck := rowKey(v), colKey(v)
+			row, ok := m[rk]
+			if !ok {
+				row = make(map[CK]A)
+				m[rk] = row
+			}
+			acc, ok := row[ck]
+			if !ok {
+				acc = downstream.Supplier()
+			} else if downstream.done(acc) {
+				return m
+			}
+			row[ck] = downstream.Accumulator(acc, v)
+			return m
+		},
+		Finisher: func(m map[RK]map[CK]A) PivotTable[RK, CK, R] {
+			cols := make(map[CK]struct{})
+			cells := make(map[RK]map[CK]R, len(m))
+			for rk, row := range m {
+				out := make(map[CK]R, len(row))
+				for ck, acc := range row {
+					cols[ck] = struct{}{}
+					out[ck] = downstream.Finisher(acc)
+				}
+				cells[rk] = out
+			}
+			return PivotTable[RK, CK, R]{
+				RowKeys: slices.Sorted(maps.Keys(m)),
+				ColKeys: slices.Sorted(maps.Keys(cols)),
+				Cells:   cells,
+			}
+		},
+	}
+	if downstream.Combiner != nil {
+		c.Combiner = func(a, b map[RK]map[CK]A) map[RK]map[CK]A {
+			for rk, row := range b {
+				cur, ok := a[rk]
+				if !ok {
+					a[rk] = row
+					continue
+				}
+				for ck, acc := range row {
+					if existing, ok := cur[ck]; ok {
+						cur[ck] = downstream.Combiner(existing, acc)
+					} else {
+						cur[ck] = acc
+					}
+				}
+			}
+			return a
+		}
+	}
+	return c
+}
+
+// CrossTabCollector returns a Collector that counts elements per (row, column) pair.
+func CrossTabCollector[T any, RK, CK cmp.Ordered](rowKey func(T) RK, colKey func(T) CK) Collector[T, map[RK]map[CK]*countingState, PivotTable[RK, CK, int]] {
+	return PivotCollector(rowKey, colKey, CountingCollector[T]())
+}
+
+// CrossTab counts the stream's elements per (row, column) pair.
+func CrossTab[T any, RK, CK cmp.Ordered](s Stream[T], rowKey func(T) RK, colKey func(T) CK) PivotTable[RK, CK, int] {
+	return CollectTo(s, CrossTabCollector(rowKey, colKey))
+}
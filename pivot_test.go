@@ -0,0 +1,97 @@
+package streams
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pivotSale struct {
+	Region  string
+	Quarter int
+	Amount  int
+}
+
+var pivotSales = []pivotSale{
+	{"us", 2, 10},
+	{"eu", 1, 5},
+	{"us", 1, 7},
+	{"eu", 1, 3},
+	{"apac", 3, 4},
+	{"us", 2, 1},
+}
+
+func TestPivotCollector(t *testing.T) {
+	t.Parallel()
+	region := func(s pivotSale) string { return s.Region }
+	quarter := func(s pivotSale) int { return s.Quarter }
+
+	t.Run("SumPerCell", func(t *testing.T) {
+		t.Parallel()
+		table := CollectTo(FromSlice(pivotSales), PivotCollector(region, quarter,
+			MappingCollector(func(s pivotSale) int { return s.Amount }, SummingCollector[int]())))
+
+		assert.Equal(t, []string{"apac", "eu", "us"}, table.RowKeys, "Row keys should be sorted")
+		assert.Equal(t, []int{1, 2, 3}, table.ColKeys, "Column keys should be sorted")
+		assert.Equal(t, 8, table.Cell("eu", 1).Get())
+		assert.Equal(t, 11, table.Cell("us", 2).Get())
+		assert.True(t, table.Cell("eu", 3).IsEmpty(), "Missing cells should be empty")
+		_, ok := table.Get("mars", 1)
+		assert.False(t, ok, "Unknown rows should not exist")
+	})
+
+	t.Run("CrossTab", func(t *testing.T) {
+		t.Parallel()
+		table := CrossTab(FromSlice(pivotSales), region, quarter)
+		assert.Equal(t, map[string]map[int]int{
+			"apac": {3: 1},
+			"eu":   {1: 2},
+			"us":   {1: 1, 2: 2},
+		}, table.Cells, "CrossTab should count per cell")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+		table := CrossTab(Empty[pivotSale](), region, quarter)
+		assert.Empty(t, table.RowKeys)
+		assert.Empty(t, table.ColKeys)
+		assert.Equal(t, [][]string{{"region"}}, table.Records("region", nil).Collect(), "Empty table should only have a header")
+	})
+
+	t.Run("Records", func(t *testing.T) {
+		t.Parallel()
+		table := CrossTab(FromSlice(pivotSales), region, quarter)
+		records := table.Records("region", func(n int) string { return strconv.Itoa(n) })
+		assert.Equal(t, [][]string{
+			{"region", "1", "2", "3"},
+			{"apac", "", "", "1"},
+			{"eu", "2", "", ""},
+			{"us", "1", "2", ""},
+		}, records.Collect(), "Records should emit a header and one row per row key")
+
+		var buf bytes.Buffer
+		require.NoError(t, ToCSV(table.Records("region", nil), &buf))
+		assert.Equal(t, "region,1,2,3\napac,,,1\neu,2,,\nus,1,2,\n", buf.String())
+		assert.Len(t, table.Records("region", nil).Limit(2).Collect(), 2, "Records should stop early")
+	})
+
+	t.Run("ParallelWithCombiner", func(t *testing.T) {
+		t.Parallel()
+		pivot := PivotCollector(
+			func(n int) int { return n % 2 },
+			func(n int) int { return n % 3 },
+			AnyMatchCollector(func(n int) bool { return n > 990 }),
+		)
+		require.NotNil(t, pivot.Combiner, "PivotCollector should be mergeable when the downstream is")
+		table := ParallelCollectTo(Range(0, 1000), pivot, WithConcurrency(4))
+		assert.Equal(t, []int{0, 1}, table.RowKeys)
+		assert.Equal(t, []int{0, 1, 2}, table.ColKeys)
+		assert.True(t, table.Cell(0, 0).Get(), "Parallel pivot should merge per-cell accumulators")
+
+		assert.NotNil(t, CrossTabCollector(region, quarter).Combiner, "CrossTab should be mergeable")
+		assert.Nil(t, PivotCollector(region, quarter, ToSliceCollector[pivotSale]()).Combiner, "PivotCollector should not be mergeable without a downstream Combiner")
+	})
+}
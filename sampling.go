@@ -0,0 +1,168 @@
+package streams
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// --- Statistical Sampling ---
+//
+// Sampling operators take an explicit *rand.Rand so results are reproducible in tests
+// (e.g. rand.New(rand.NewPCG(seed, seed))). A nil rng uses the global random source.
+// A *rand.Rand is not safe for concurrent use, so do not share one across goroutines.
+
+// randIntN returns a random int in [0, n) from rng, or from the global source if rng is nil.
+func randIntN(rng *rand.Rand, n int) int {
+	if rng == nil {
+		return rand.IntN(n)
+	}
+	return rng.IntN(n)
+}
+
+// randFloat64 returns a random float64 in [0, 1) from rng, or from the global source if rng is nil.
+func randFloat64(rng *rand.Rand) float64 {
+	if rng == nil {
+		return rand.Float64()
+	}
+	return rng.Float64()
+}
+
+// reservoirState holds state for uniform reservoir sampling.
+type reservoirState[T any] struct {
+	sample []T
+	seen   int
+}
+
+// ReservoirSampleCollector returns a Collector that selects k elements uniformly at random
+// in one pass using O(k) memory (reservoir sampling, Algorithm R).
+// If the stream has fewer than k elements, all are returned.
+// The order of the returned sample is unspecified.
+func ReservoirSampleCollector[T any](k int, rng *rand.Rand) Collector[T, *reservoirState[T], []T] {
+	k = max(k, 0)
+	return Collector[T, *reservoirState[T], []T]{
+		Supplier: func() *reservoirState[T] {
+			return &reservoirState[T]{sample: make([]T, 0, k)}
+		},
+		Accumulator: func(st *reservoirState[T], v T) *reservoirState[T] {
+			st.seen++
+			if len(st.sample) < k {
+				st.sample = append(st.sample, v)
+			} else if j := randIntN(rng, st.seen); j < k {
+				st.sample[j] = v
+			}
+			return st
+		},
+		Finisher: func(st *reservoirState[T]) []T { return st.sample },
+	}
+}
+
+// ReservoirSample selects k elements of the stream uniformly at random in one pass.
+func ReservoirSample[T any](s Stream[T], k int, rng *rand.Rand) []T {
+	return CollectTo(s, ReservoirSampleCollector[T](k, rng))
+}
+
+// weightedItem is a candidate of a weighted reservoir with its random key.
+type weightedItem[T any] struct {
+	value T
+	key   float64
+}
+
+// weightedReservoirState holds state for weighted reservoir sampling using a min-heap by key.
+type weightedReservoirState[T any] struct {
+	heap []weightedItem[T]
+}
+
+func (st *weightedReservoirState[T]) heapifyDown(i int) {
+	n := len(st.heap)
+	for {
+		smallest := i
+		left, right := 2*i+1, 2*i+2
+		if left < n && st.heap[left].key < st.heap[smallest].key {
+			smallest = left
+		}
+		if right < n && st.heap[right].key < st.heap[smallest].key {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		st.heap[i], st.heap[smallest] = st.heap[smallest], st.heap[i]
+		i = smallest
+	}
+}
+
+func (st *weightedReservoirState[T]) heapifyUp(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if st.heap[i].key >= st.heap[parent].key {
+			return
+		}
+		st.heap[i], st.heap[parent] = st.heap[parent], st.heap[i]
+		i = parent
+	}
+}
+
+// WeightedReservoirSampleCollector returns a Collector that selects k elements without
+// replacement, each with probability proportional to its weight, in one pass using O(k) memory
+// (Efraimidis-Spirakis A-ES). Elements with a non-positive or NaN weight are never selected.
+// The sample is returned in descending key order, so heavier elements tend to come first.
+func WeightedReservoirSampleCollector[T any](k int, weight func(T) float64, rng *rand.Rand) Collector[T, *weightedReservoirState[T], []T] {
+	k = max(k, 0)
+	return Collector[T, *weightedReservoirState[T], []T]{
+		Supplier: func() *weightedReservoirState[T] {
+			return &weightedReservoirState[T]{heap: make([]weightedItem[T], 0, k)}
+		},
+		Accumulator: func(st *weightedReservoirState[T], v T) *weightedReservoirState[T] {
+			w := weight(v)
+			if k == 0 || !(w > 0) {
+				return st
+			}
+			// key = u^(1/w), compared in log space to avoid underflow for small weights
+			key := math.Log(1-randFloat64(rng)) / w
+			if len(st.heap) < k {
+				st.heap = append(st.heap, weightedItem[T]{value: v, key: key})
+				st.heapifyUp(len(st.heap) - 1)
+			} else if key > st.heap[0].key {
+				st.heap[0] = weightedItem[T]{value: v, key: key}
+				st.heapifyDown(0)
+			}
+			return st
+		},
+		Finisher: func(st *weightedReservoirState[T]) []T {
+			items := slices.Clone(st.heap)
+			slices.SortFunc(items, func(a, b weightedItem[T]) int {
+				return cmp.Compare(b.key, a.key)
+			})
+			result := make([]T, len(items))
+			for i, item := range items {
+				result[i] = item.value
+			}
+			return result
+		},
+	}
+}
+
+// WeightedReservoirSample selects k elements without replacement with probability
+// proportional to their weight, in one pass.
+func WeightedReservoirSample[T any](s Stream[T], k int, weight func(T) float64, rng *rand.Rand) []T {
+	return CollectTo(s, WeightedReservoirSampleCollector(k, weight, rng))
+}
+
+// BernoulliSample returns a lazy stream that keeps each element independently with probability p.
+// p <= 0 keeps nothing and p >= 1 keeps everything.
+func BernoulliSample[T any](s Stream[T], p float64, rng *rand.Rand) Stream[T] {
+	return Stream[T]{
+		seq: func(yield func(T) bool) {
+			if !(p > 0) {
+				return
+			}
+			for v := range s.seq {
+				if (p >= 1 || randFloat64(rng) < p) && !yield(v) {
+					return
+				}
+			}
+		},
+	}
+}
@@ -0,0 +1,123 @@
+package streams
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+func TestReservoirSample(t *testing.T) {
+	t.Parallel()
+	t.Run("FewerThanK", func(t *testing.T) {
+		t.Parallel()
+		result := ReservoirSample(Of(1, 2, 3), 5, newTestRand(1))
+		assert.Equal(t, []int{1, 2, 3}, result, "All elements should be kept when fewer than k")
+	})
+
+	t.Run("SizeAndMembership", func(t *testing.T) {
+		t.Parallel()
+		result := ReservoirSample(Range(0, 1000), 10, newTestRand(1))
+		assert.Len(t, result, 10, "Sample should have k elements")
+		seen := make(map[int]bool)
+		for _, v := range result {
+			assert.True(t, v >= 0 && v < 1000, "Sample should come from the stream")
+			assert.False(t, seen[v], "Sample should not repeat elements")
+			seen[v] = true
+		}
+	})
+
+	t.Run("Reproducible", func(t *testing.T) {
+		t.Parallel()
+		a := ReservoirSample(Range(0, 1000), 10, newTestRand(42))
+		b := ReservoirSample(Range(0, 1000), 10, newTestRand(42))
+		assert.Equal(t, a, b, "Same seed should give the same sample")
+	})
+
+	t.Run("Uniform", func(t *testing.T) {
+		t.Parallel()
+		rng := newTestRand(7)
+		counts := make([]int, 10)
+		const trials = 20_000
+		for range trials {
+			for _, v := range ReservoirSample(Range(0, 10), 3, rng) {
+				counts[v]++
+			}
+		}
+		for i, c := range counts {
+			assert.InDelta(t, trials*3/10, c, trials*3/10*0.05, "Element %d should be selected about 30%% of the time", i)
+		}
+	})
+
+	t.Run("ZeroK", func(t *testing.T) {
+		t.Parallel()
+		assert.Empty(t, ReservoirSample(Range(0, 10), 0, nil), "k=0 should select nothing")
+		assert.Len(t, ReservoirSample(Range(0, 10), 3, nil), 3, "nil rng should use the global source")
+	})
+}
+
+func TestWeightedReservoirSample(t *testing.T) {
+	t.Parallel()
+	t.Run("SkipsNonPositiveWeights", func(t *testing.T) {
+		t.Parallel()
+		result := WeightedReservoirSample(Of(-1, 0, 2, 3), 4, func(n int) float64 { return float64(n) }, newTestRand(1))
+		assert.ElementsMatch(t, []int{2, 3}, result, "Non-positive weights should never be selected")
+	})
+
+	t.Run("ProportionalToWeight", func(t *testing.T) {
+		t.Parallel()
+		rng := newTestRand(3)
+		weight := func(n int) float64 { return float64(n) }
+		counts := make(map[int]int)
+		const trials = 20_000
+		for range trials {
+			counts[WeightedReservoirSample(Of(1, 3), 1, weight, rng)[0]]++
+		}
+		assert.InDelta(t, 0.75, float64(counts[3])/trials, 0.02, "Weight 3 should be chosen 3x as often as weight 1")
+	})
+
+	t.Run("Reproducible", func(t *testing.T) {
+		t.Parallel()
+		weight := func(n int) float64 { return float64(n%7 + 1) }
+		a := WeightedReservoirSample(Range(0, 500), 5, weight, newTestRand(9))
+		b := WeightedReservoirSample(Range(0, 500), 5, weight, newTestRand(9))
+		assert.Equal(t, a, b, "Same seed should give the same sample")
+		assert.Len(t, a, 5, "Sample should have k elements")
+	})
+
+	t.Run("RunningMatchesFinal", func(t *testing.T) {
+		t.Parallel()
+		weight := func(n int) float64 { return float64(n) }
+		running := CollectRunning(Range(1, 200), WeightedReservoirSampleCollector(3, weight, newTestRand(4)), 1).Collect()
+		final := CollectTo(Range(1, 200), WeightedReservoirSampleCollector(3, weight, newTestRand(4)))
+		assert.Equal(t, final, running[len(running)-1], "Finishing mid-stream should not disturb the reservoir")
+	})
+}
+
+func TestBernoulliSample(t *testing.T) {
+	t.Parallel()
+	t.Run("Rate", func(t *testing.T) {
+		t.Parallel()
+		n := BernoulliSample(Range(0, 100_000), 0.1, newTestRand(5)).Count()
+		assert.InDelta(t, 10_000, n, 500, "About 10% of elements should be kept")
+	})
+
+	t.Run("Bounds", func(t *testing.T) {
+		t.Parallel()
+		assert.Empty(t, BernoulliSample(Range(0, 10), 0, nil).Collect(), "p=0 should keep nothing")
+		assert.Equal(t, []int{0, 1, 2}, BernoulliSample(Range(0, 3), 1, nil).Collect(), "p=1 should keep everything")
+	})
+
+	t.Run("LazyAndOrdered", func(t *testing.T) {
+		t.Parallel()
+		result := BernoulliSample(Iterate(0, func(n int) int { return n + 1 }), 0.5, newTestRand(1)).Limit(5).Collect()
+		assert.Len(t, result, 5, "BernoulliSample should work on infinite streams")
+		assert.IsIncreasing(t, result, "BernoulliSample should preserve order")
+		again := BernoulliSample(Iterate(0, func(n int) int { return n + 1 }), 0.5, newTestRand(1)).Limit(5).Collect()
+		assert.Equal(t, result, again, "Same seed should give the same sample")
+	})
+}
@@ -0,0 +1,1455 @@
+package streams
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// --- Probabilistic Sketches ---
+//
+// Sketches summarize a stream in bounded memory. Every sketch can be merged with
+// another sketch of the same configuration (so it can be used with ParallelCollectTo)
+// and serialized with MarshalBinary/UnmarshalBinary so partial results can be stored
+// and combined later.
+
+// ErrInvalidSketch is returned when decoding or merging incompatible sketch data.
+var ErrInvalidSketch = errors.New("streams: invalid or incompatible sketch")
+
+// sketchVersion is the leading byte of every serialized sketch.
+const sketchVersion byte = 1
+
+// appendFloat64 appends the big-endian IEEE 754 encoding of f to b.
+func appendFloat64(b []byte, f float64) []byte {
+	return binary.BigEndian.AppendUint64(b, math.Float64bits(f))
+}
+
+// sketchReader decodes sketch fields sequentially, recording the first error.
+type sketchReader struct {
+	data []byte
+	err  error
+}
+
+func (r *sketchReader) uint64() uint64 {
+	if r.err != nil || len(r.data) < 8 {
+		r.err = ErrInvalidSketch
+		return 0
+	}
+	v := binary.BigEndian.Uint64(r.data)
+	r.data = r.data[8:]
+	return v
+}
+
+func (r *sketchReader) uint32() uint32 {
+	if r.err != nil || len(r.data) < 4 {
+		r.err = ErrInvalidSketch
+		return 0
+	}
+	v := binary.BigEndian.Uint32(r.data)
+	r.data = r.data[4:]
+	return v
+}
+
+func (r *sketchReader) float64() float64 {
+	return math.Float64frombits(r.uint64())
+}
+
+func (r *sketchReader) byte() byte {
+	if r.err != nil || len(r.data) < 1 {
+		r.err = ErrInvalidSketch
+		return 0
+	}
+	v := r.data[0]
+	r.data = r.data[1:]
+	return v
+}
+
+// header checks the version and sketch kind bytes.
+func (r *sketchReader) header(kind byte) {
+	if r.byte() != sketchVersion || r.byte() != kind {
+		r.err = ErrInvalidSketch
+	}
+}
+
+// finish returns the first decoding error, or an error if unread data remains.
+func (r *sketchReader) finish() error {
+	if r.err == nil && len(r.data) != 0 {
+		r.err = ErrInvalidSketch
+	}
+	return r.err
+}
+
+// Sketch kinds used in the serialized header.
+const (
+	sketchKindTDigest byte = iota + 1
+	sketchKindHyperLogLog
+	sketchKindCountMin
+	sketchKindBloomFilter
+)
+
+// --- Hashing ---
+
+// mix64 is the MurmurHash3 finalizer; it spreads input bits across the whole word.
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// hashString hashes s with 64-bit FNV-1a followed by mix64.
+func hashString(s string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	return mix64(h)
+}
+
+// DefaultHash returns a 64-bit hash of v suitable for sketches.
+// Strings, byte slices, integers, floats and booleans are hashed directly;
+// other types are hashed through their fmt "%v" representation, which is slower.
+// The hash is deterministic across processes, so serialized sketches built with it
+// can be merged later.
+func DefaultHash[T any](v T) uint64 {
+	switch x := any(v).(type) {
+	case string:
+		return hashString(x)
+	case []byte:
+		return hashString(string(x))
+	case int:
+		return mix64(uint64(x))
+	case int8:
+		return mix64(uint64(x))
+	case int16:
+		return mix64(uint64(x))
+	case int32:
+		return mix64(uint64(x))
+	case int64:
+		return mix64(uint64(x))
+	case uint:
+		return mix64(uint64(x))
+	case uint8:
+		return mix64(uint64(x))
+	case uint16:
+		return mix64(uint64(x))
+	case uint32:
+		return mix64(uint64(x))
+	case uint64:
+		return mix64(x)
+	case uintptr:
+		return mix64(uint64(x))
+	case float32:
+		return hashFloat(float64(x))
+	case float64:
+		return hashFloat(x)
+	case bool:
+		if x {
+			return mix64(1)
+		}
+		return mix64(0)
+	default:
+		return hashString(fmt.Sprint(v))
+	}
+}
+
+// hashFloat hashes f so that 0 and -0 collide.
+func hashFloat(f float64) uint64 {
+	if f == 0 {
+		f = 0
+	}
+	return mix64(math.Float64bits(f))
+}
+
+// --- T-Digest (Approximate Quantiles) ---
+
+// DefaultTDigestCompression is the compression used when a non-positive value is given.
+const DefaultTDigestCompression = 100
+
+// centroid is a cluster of values summarized by their mean and total weight.
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// TDigest is a mergeable sketch for estimating quantiles in bounded memory.
+// Higher compression gives better accuracy at the cost of more centroids (about 2x compression).
+// Accuracy is best near the tails, which suits latency percentiles such as p99.
+//
+// The zero value is an empty digest with DefaultTDigestCompression. A TDigest is not safe
+// for concurrent use: values are buffered and folded into the centroids lazily, so Quantile,
+// Quantiles and MarshalBinary modify the digest too and need the same synchronization as Add.
+type TDigest struct {
+	compression float64
+	centroids   []centroid // merged centroids sorted by mean
+	buffer      []centroid // unmerged values
+	count       float64
+	min, max    float64
+}
+
+// NewTDigest creates an empty TDigest with the given compression.
+// A compression <= 0 uses DefaultTDigestCompression.
+func NewTDigest(compression float64) *TDigest {
+	if compression <= 0 {
+		compression = DefaultTDigestCompression
+	}
+	return &TDigest{
+		compression: compression,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+// init applies the default compression to a zero-value digest.
+func (d *TDigest) init() {
+	if d.compression <= 0 {
+		d.compression = DefaultTDigestCompression
+	}
+}
+
+// Add adds a value to the digest. NaN values are ignored.
+func (d *TDigest) Add(x float64) {
+	if math.IsNaN(x) {
+		return
+	}
+	d.init()
+	if d.count == 0 {
+		d.min, d.max = x, x
+	}
+	d.buffer = append(d.buffer, centroid{mean: x, weight: 1})
+	d.count++
+	d.min = min(d.min, x)
+	d.max = max(d.max, x)
+	if len(d.buffer) >= int(5*d.compression) {
+		d.compress()
+	}
+}
+
+// Merge adds all values summarized by other into d.
+// The digest keeps its own compression.
+func (d *TDigest) Merge(other *TDigest) {
+	if other == nil || other.count == 0 {
+		return
+	}
+	d.init()
+	if d.count == 0 {
+		d.min, d.max = other.min, other.max
+	}
+	d.buffer = append(d.buffer, other.centroids...)
+	d.buffer = append(d.buffer, other.buffer...)
+	d.count += other.count
+	d.min = min(d.min, other.min)
+	d.max = max(d.max, other.max)
+	d.compress()
+}
+
+// Count returns the number of values added to the digest.
+func (d *TDigest) Count() int64 {
+	return int64(d.count)
+}
+
+// Compression returns the compression of the digest.
+func (d *TDigest) Compression() float64 {
+	d.init()
+	return d.compression
+}
+
+// Min returns the smallest value added, or None if the digest is empty.
+func (d *TDigest) Min() Optional[float64] {
+	return OptionalFromCondition(d.count > 0, d.min)
+}
+
+// Max returns the largest value added, or None if the digest is empty.
+func (d *TDigest) Max() Optional[float64] {
+	return OptionalFromCondition(d.count > 0, d.max)
+}
+
+// scale maps a quantile to the k1 scale, which keeps clusters small near the tails.
+func (d *TDigest) scale(q float64) float64 {
+	return d.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// scaleInverse maps a k1 scale value back to a quantile.
+func (d *TDigest) scaleInverse(k float64) float64 {
+	if k >= d.compression/4 {
+		return 1
+	}
+	return (math.Sin(k*2*math.Pi/d.compression) + 1) / 2
+}
+
+// compress merges buffered values into the centroid list.
+func (d *TDigest) compress() {
+	if len(d.buffer) == 0 {
+		return
+	}
+	all := append(d.centroids, d.buffer...)
+	slices.SortFunc(all, func(a, b centroid) int {
+		switch {
+		case a.mean < b.mean:
+			return -1
+		case a.mean > b.mean:
+			return 1
+		}
+		return 0
+	})
+
+	merged := make([]centroid, 0, min(len(all), int(2*d.compression)+1))
+	cur := all[0]
+	soFar := 0.0
+	limit := d.count * d.scaleInverse(d.scale(0)+1)
+	for _, c := range all[1:] {
+		if proposed := cur.weight + c.weight; soFar+proposed <= limit {
+			cur.mean += (c.mean - cur.mean) * c.weight / proposed
+			cur.weight = proposed
+			continue
+		}
+		soFar += cur.weight
+		merged = append(merged, cur)
+		limit = d.count * d.scaleInverse(d.scale(soFar/d.count)+1)
+		cur = c
+	}
+	d.centroids = append(merged, cur)
+	d.buffer = d.buffer[:0]
+}
+
+// Quantile estimates the q-th quantile (q in [0, 1]).
+// Returns None if the digest is empty.
+func (d *TDigest) Quantile(q float64) Optional[float64] {
+	if d.count == 0 {
+		return None[float64]()
+	}
+	d.compress()
+	if q <= 0 {
+		return Some(d.min)
+	}
+	if q >= 1 {
+		return Some(d.max)
+	}
+
+	cs := d.centroids
+	if len(cs) == 1 {
+		return Some(cs[0].mean)
+	}
+
+	// Interpolate between centroid midpoints, anchored at min and max
+	target := q * d.count
+	cum := 0.0
+	for i, c := range cs {
+		mid := cum + c.weight/2
+		if target < mid {
+			if i == 0 {
+				return Some(d.min + (c.mean-d.min)*target/mid)
+			}
+			prev := cs[i-1]
+			prevMid := cum - prev.weight/2
+			return Some(prev.mean + (c.mean-prev.mean)*(target-prevMid)/(mid-prevMid))
+		}
+		cum += c.weight
+	}
+	last := cs[len(cs)-1]
+	lastMid := d.count - last.weight/2
+	if d.count == lastMid {
+		return Some(d.max)
+	}
+	return Some(last.mean + (d.max-last.mean)*(target-lastMid)/(d.count-lastMid))
+}
+
+// Quantiles estimates several quantiles at once.
+// Returns None if the digest is empty.
+func (d *TDigest) Quantiles(qs ...float64) Optional[[]float64] {
+	if d.count == 0 {
+		return None[[]float64]()
+	}
+	result := make([]float64, len(qs))
+	for i, q := range qs {
+		result[i] = d.Quantile(q).Get()
+	}
+	return Some(result)
+}
+
+// MarshalBinary encodes the digest so it can be stored and merged later.
+func (d *TDigest) MarshalBinary() ([]byte, error) {
+	d.init()
+	d.compress()
+	b := make([]byte, 0, 2+8*5+16*len(d.centroids))
+	b = append(b, sketchVersion, sketchKindTDigest)
+	b = appendFloat64(b, d.compression)
+	b = appendFloat64(b, d.count)
+	b = appendFloat64(b, d.min)
+	b = appendFloat64(b, d.max)
+	b = binary.BigEndian.AppendUint64(b, uint64(len(d.centroids)))
+	for _, c := range d.centroids {
+		b = appendFloat64(b, c.mean)
+		b = appendFloat64(b, c.weight)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes a digest produced by MarshalBinary, replacing the receiver's state.
+func (d *TDigest) UnmarshalBinary(data []byte) error {
+	r := &sketchReader{data: data}
+	r.header(sketchKindTDigest)
+	decoded := TDigest{
+		compression: r.float64(),
+		count:       r.float64(),
+		min:         r.float64(),
+		max:         r.float64(),
+	}
+	n := r.uint64()
+	if r.err != nil || decoded.compression <= 0 || n > uint64(len(r.data)/16) {
+		return ErrInvalidSketch
+	}
+	decoded.centroids = make([]centroid, n)
+	for i := range decoded.centroids {
+		decoded.centroids[i] = centroid{mean: r.float64(), weight: r.float64()}
+	}
+	if err := r.finish(); err != nil {
+		return err
+	}
+	if !decoded.consistent() {
+		return ErrInvalidSketch
+	}
+	*d = decoded
+	return nil
+}
+
+// consistent reports whether decoded centroids agree with the count, min, and max,
+// so that queries on the digest cannot index out of range or return garbage.
+func (d *TDigest) consistent() bool {
+	if !(d.count >= 0) || math.IsInf(d.count, 1) {
+		return false
+	}
+	if len(d.centroids) == 0 {
+		return d.count == 0
+	}
+	if !(d.min <= d.max) {
+		return false
+	}
+	total := 0.0
+	prev := d.min
+	for _, c := range d.centroids {
+		if !(c.weight > 0) || math.IsInf(c.weight, 1) || !(c.mean >= prev && c.mean <= d.max) {
+			return false
+		}
+		total += c.weight
+		prev = c.mean
+	}
+	return math.Abs(total-d.count) <= 1e-9*d.count
+}
+
+// TDigestCollector returns a Collector that builds a TDigest from numeric elements.
+// The resulting digest can be queried, merged, or serialized.
+func TDigestCollector[T Numeric](compression float64) Collector[T, *TDigest, *TDigest] {
+	return Collector[T, *TDigest, *TDigest]{
+		Supplier: func() *TDigest { return NewTDigest(compression) },
+		Accumulator: func(d *TDigest, v T) *TDigest {
+			d.Add(float64(v))
+			return d
+		},
+		Combiner: func(a, b *TDigest) *TDigest {
+			a.Merge(b)
+			return a
+		},
+		Finisher: func(d *TDigest) *TDigest { return d },
+	}
+}
+
+// ApproxQuantileCollector returns a Collector that estimates several quantiles in one pass
+// using a TDigest with the given compression. Memory is bounded by the compression,
+// unlike QuantileCollector which stores all elements.
+// Returns None for an empty stream.
+func ApproxQuantileCollector[T Numeric](compression float64, qs ...float64) Collector[T, *TDigest, Optional[[]float64]] {
+	c := TDigestCollector[T](compression)
+	return Collector[T, *TDigest, Optional[[]float64]]{
+		Supplier:    c.Supplier,
+		Accumulator: c.Accumulator,
+		Combiner:    c.Combiner,
+		Finisher: func(d *TDigest) Optional[[]float64] {
+			return d.Quantiles(qs...)
+		},
+	}
+}
+
+// ApproxQuantiles estimates several quantiles of a numeric stream in bounded memory.
+func ApproxQuantiles[T Numeric](s Stream[T], compression float64, qs ...float64) Optional[[]float64] {
+	return CollectTo(s, ApproxQuantileCollector[T](compression, qs...))
+}
+
+// --- HyperLogLog (Approximate Distinct Count) ---
+
+const (
+	// DefaultHyperLogLogPrecision is the precision used when a non-positive value is given.
+	// It uses 16 KiB of registers and has a standard error of about 0.8%.
+	DefaultHyperLogLogPrecision = 14
+	// MinHyperLogLogPrecision is the smallest supported precision.
+	MinHyperLogLogPrecision = 4
+	// MaxHyperLogLogPrecision is the largest supported precision.
+	MaxHyperLogLogPrecision = 18
+
+	// hllSparsePrecision is the precision of the sparse representation.
+	hllSparsePrecision = 25
+)
+
+// HyperLogLog is a mergeable sketch for estimating the number of distinct values.
+// It follows HyperLogLog++: 64-bit hashes and a sparse, high-precision representation
+// for small cardinalities that switches to 2^precision dense registers as it grows.
+// Estimates use Ertl's improved estimator, which needs no empirical bias tables.
+// The standard error is about 1.04/sqrt(2^precision).
+// The zero value is an empty sketch with DefaultHyperLogLogPrecision.
+type HyperLogLog struct {
+	p         uint8
+	sparse    map[uint32]uint8 // sparse index -> max rank; nil once dense
+	registers []uint8          // dense registers; nil while sparse
+}
+
+// NewHyperLogLog creates an empty HyperLogLog with the given precision.
+// A precision <= 0 uses DefaultHyperLogLogPrecision; other values are clamped
+// to [MinHyperLogLogPrecision, MaxHyperLogLogPrecision].
+func NewHyperLogLog(precision int) *HyperLogLog {
+	if precision <= 0 {
+		precision = DefaultHyperLogLogPrecision
+	}
+	precision = min(max(precision, MinHyperLogLogPrecision), MaxHyperLogLogPrecision)
+	return &HyperLogLog{p: uint8(precision), sparse: make(map[uint32]uint8)}
+}
+
+// init applies the default precision to a zero-value sketch.
+func (h *HyperLogLog) init() {
+	if h.p == 0 {
+		h.p = DefaultHyperLogLogPrecision
+		h.sparse = make(map[uint32]uint8)
+	}
+}
+
+// Precision returns the precision of the sketch.
+func (h *HyperLogLog) Precision() int {
+	h.init()
+	return int(h.p)
+}
+
+// AddHash adds a pre-computed 64-bit hash to the sketch.
+// Hashes should be uniformly distributed, e.g. from DefaultHash.
+func (h *HyperLogLog) AddHash(x uint64) {
+	h.init()
+	if h.registers != nil {
+		idx, rank := hllDense(x, h.p)
+		h.registers[idx] = max(h.registers[idx], rank)
+		return
+	}
+	idx, rank := hllDense(x, hllSparsePrecision)
+	h.sparse[idx] = max(h.sparse[idx], rank)
+	if len(h.sparse) > h.sparseLimit() {
+		h.toDense()
+	}
+}
+
+// hllDense splits a hash into a register index and rank for precision p.
+func hllDense(x uint64, p uint8) (uint32, uint8) {
+	return uint32(x >> (64 - p)), uint8(bits.LeadingZeros64(x<<p|1<<(p-1)) + 1)
+}
+
+// sparseLimit is the number of sparse entries above which the sketch switches to dense registers.
+// A map entry costs about 10 bytes against one byte per dense register, so the
+// sparse form stays below the dense size only up to roughly 2^p/10 entries.
+func (h *HyperLogLog) sparseLimit() int {
+	return (1 << h.p) / 16
+}
+
+// sparseToDense maps a sparse entry to its dense register index and rank.
+func (h *HyperLogLog) sparseToDense(idx uint32, rank uint8) (uint32, uint8) {
+	shift := hllSparsePrecision - h.p
+	if low := idx & (1<<shift - 1); low != 0 {
+		return idx >> shift, uint8(bits.LeadingZeros32(low) - (32 - int(shift)) + 1)
+	}
+	return idx >> shift, rank + shift
+}
+
+// toDense converts the sparse representation to dense registers.
+func (h *HyperLogLog) toDense() {
+	h.registers = make([]uint8, 1<<h.p)
+	for idx, rank := range h.sparse {
+		i, r := h.sparseToDense(idx, rank)
+		h.registers[i] = max(h.registers[i], r)
+	}
+	h.sparse = nil
+}
+
+// Merge adds all values summarized by other into h.
+// Returns ErrInvalidSketch if the precisions differ.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if other == nil || other.p == 0 {
+		return nil
+	}
+	h.init()
+	if other.p != h.p {
+		return ErrInvalidSketch
+	}
+	if h.registers == nil && other.registers == nil {
+		for idx, rank := range other.sparse {
+			h.sparse[idx] = max(h.sparse[idx], rank)
+		}
+		if len(h.sparse) > h.sparseLimit() {
+			h.toDense()
+		}
+		return nil
+	}
+	if h.registers == nil {
+		h.toDense()
+	}
+	if other.registers != nil {
+		for i, r := range other.registers {
+			h.registers[i] = max(h.registers[i], r)
+		}
+		return nil
+	}
+	for idx, rank := range other.sparse {
+		i, r := h.sparseToDense(idx, rank)
+		h.registers[i] = max(h.registers[i], r)
+	}
+	return nil
+}
+
+// Estimate returns the estimated number of distinct values added.
+func (h *HyperLogLog) Estimate() int64 {
+	if h.registers == nil {
+		// Linear counting over the sparse registers is nearly exact at this size
+		m := float64(uint64(1) << hllSparsePrecision)
+		return int64(math.Round(m * math.Log(m/(m-float64(len(h.sparse))))))
+	}
+
+	q := 64 - int(h.p)
+	counts := make([]int, q+2)
+	for _, r := range h.registers {
+		counts[r]++
+	}
+	m := float64(len(h.registers))
+	z := m * hllTau(1-float64(counts[q+1])/m)
+	for k := q; k >= 1; k-- {
+		z = 0.5 * (z + float64(counts[k]))
+	}
+	z += m * hllSigma(float64(counts[0])/m)
+	return int64(math.Round(m * m / (2 * math.Ln2 * z)))
+}
+
+// hllSigma is the sigma function of Ertl's improved estimator.
+func hllSigma(x float64) float64 {
+	if x == 1 {
+		return math.Inf(1)
+	}
+	y, z := 1.0, x
+	for {
+		x *= x
+		prev := z
+		z += x * y
+		y += y
+		if z == prev {
+			return z
+		}
+	}
+}
+
+// hllTau is the tau function of Ertl's improved estimator.
+func hllTau(x float64) float64 {
+	if x == 0 || x == 1 {
+		return 0
+	}
+	y, z := 1.0, 1-x
+	for {
+		x = math.Sqrt(x)
+		prev := z
+		y *= 0.5
+		z -= (1 - x) * (1 - x) * y
+		if z == prev {
+			return z / 3
+		}
+	}
+}
+
+// MarshalBinary encodes the sketch so it can be stored and merged later.
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	h.init()
+	if h.registers != nil {
+		b := make([]byte, 0, 4+len(h.registers))
+		b = append(b, sketchVersion, sketchKindHyperLogLog, h.p, 1)
+		return append(b, h.registers...), nil
+	}
+	idxs := slices.Sorted(maps.Keys(h.sparse))
+	b := make([]byte, 0, 12+5*len(idxs))
+	b = append(b, sketchVersion, sketchKindHyperLogLog, h.p, 0)
+	b = binary.BigEndian.AppendUint64(b, uint64(len(idxs)))
+	for _, idx := range idxs {
+		b = binary.BigEndian.AppendUint32(b, idx)
+		b = append(b, h.sparse[idx])
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes a sketch produced by MarshalBinary, replacing the receiver's state.
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	r := &sketchReader{data: data}
+	r.header(sketchKindHyperLogLog)
+	p, dense := r.byte(), r.byte()
+	if r.err != nil || p < MinHyperLogLogPrecision || p > MaxHyperLogLogPrecision {
+		return ErrInvalidSketch
+	}
+	decoded := HyperLogLog{p: p}
+	switch dense {
+	case 1:
+		if len(r.data) != 1<<p {
+			return ErrInvalidSketch
+		}
+		// Ranks are at most 65-p (all hash bits after the index are zero)
+		if slices.Max(r.data) > 65-p {
+			return ErrInvalidSketch
+		}
+		decoded.registers = slices.Clone(r.data)
+		r.data = nil
+	case 0:
+		n := r.uint64()
+		if r.err != nil || n > uint64(len(r.data)/5) {
+			return ErrInvalidSketch
+		}
+		decoded.sparse = make(map[uint32]uint8, n)
+		for range n {
+			idx, rank := r.uint32(), r.byte()
+			if idx >= 1<<hllSparsePrecision || rank > 65-hllSparsePrecision {
+				return ErrInvalidSketch
+			}
+			decoded.sparse[idx] = rank
+		}
+	default:
+		return ErrInvalidSketch
+	}
+	if err := r.finish(); err != nil {
+		return err
+	}
+	*h = decoded
+	return nil
+}
+
+// HyperLogLogCollector returns a Collector that builds a HyperLogLog from elements.
+// If hash is nil, DefaultHash is used. The resulting sketch can be queried, merged, or serialized.
+func HyperLogLogCollector[T any](precision int, hash func(T) uint64) Collector[T, *HyperLogLog, *HyperLogLog] {
+	if hash == nil {
+		hash = DefaultHash[T]
+	}
+	return Collector[T, *HyperLogLog, *HyperLogLog]{
+		Supplier: func() *HyperLogLog { return NewHyperLogLog(precision) },
+		Accumulator: func(h *HyperLogLog, v T) *HyperLogLog {
+			h.AddHash(hash(v))
+			return h
+		},
+		Combiner: func(a, b *HyperLogLog) *HyperLogLog {
+			_ = a.Merge(b) // same precision by construction
+			return a
+		},
+		Finisher: func(h *HyperLogLog) *HyperLogLog { return h },
+	}
+}
+
+// ApproxDistinctCountCollector returns a Collector that estimates the number of distinct
+// elements in bounded memory using HyperLogLog. If hash is nil, DefaultHash is used.
+// Unlike ToSetCollector or FrequencyCollector, memory does not grow with the number of unique values.
+func ApproxDistinctCountCollector[T any](precision int, hash func(T) uint64) Collector[T, *HyperLogLog, int64] {
+	c := HyperLogLogCollector(precision, hash)
+	return Collector[T, *HyperLogLog, int64]{
+		Supplier:    c.Supplier,
+		Accumulator: c.Accumulator,
+		Combiner:    c.Combiner,
+		Finisher:    func(h *HyperLogLog) int64 { return h.Estimate() },
+	}
+}
+
+// ApproxDistinctCountByKeyCollector returns a Collector that estimates the number of distinct
+// values per key, keeping one HyperLogLog per key. If hash is nil, DefaultHash is used.
+func ApproxDistinctCountByKeyCollector[T any, K comparable, V any](
+	keyFn func(T) K,
+	valFn func(T) V,
+	precision int,
+	hash func(V) uint64,
+) Collector[T, map[K]*HyperLogLog, map[K]int64] {
+	if hash == nil {
+		hash = DefaultHash[V]
+	}
+	return Collector[T, map[K]*HyperLogLog, map[K]int64]{
+		Supplier: func() map[K]*HyperLogLog { return make(map[K]*HyperLogLog) },
+		Accumulator: func(acc map[K]*HyperLogLog, v T) map[K]*HyperLogLog {
+			k := keyFn(v)
+			h, ok := acc[k]
+			if !ok {
+				h = NewHyperLogLog(precision)
+				acc[k] = h
+			}
+			h.AddHash(hash(valFn(v)))
+			return acc
+		},
+		Combiner: func(a, b map[K]*HyperLogLog) map[K]*HyperLogLog {
+			for k, h := range b {
+				if cur, ok := a[k]; ok {
+					_ = cur.Merge(h)
+				} else {
+					a[k] = h
+				}
+			}
+			return a
+		},
+		Finisher: func(acc map[K]*HyperLogLog) map[K]int64 {
+			result := make(map[K]int64, len(acc))
+			for k, h := range acc {
+				result[k] = h.Estimate()
+			}
+			return result
+		},
+	}
+}
+
+// ApproxDistinctCount estimates the number of distinct elements using HyperLogLog
+// with the given precision and DefaultHash.
+func ApproxDistinctCount[T any](s Stream[T], precision int) int64 {
+	return CollectTo(s, ApproxDistinctCountCollector[T](precision, nil))
+}
+
+// ApproxDistinctCountByKey estimates the number of distinct values for each key
+// using one HyperLogLog per key with the given precision and DefaultHash.
+func ApproxDistinctCountByKey[K comparable, V any](s Stream2[K, V], precision int) map[K]int64 {
+	c := ApproxDistinctCountByKeyCollector(
+		func(p Pair[K, V]) K { return p.First },
+		func(p Pair[K, V]) V { return p.Second },
+		precision, nil,
+	)
+	return CollectTo(s.ToPairs(), c)
+}
+
+// --- Space-Saving (Heavy Hitters) ---
+
+// HeavyHitter is an element reported by a heavy-hitters sketch.
+// Count is an upper bound on the true frequency and Count-Error is a lower bound.
+type HeavyHitter[T any] struct {
+	Value T
+	Count int64
+	Error int64
+}
+
+// ssEntry is a monitored element of a SpaceSaving sketch.
+type ssEntry[T comparable] struct {
+	value T
+	count int64
+	err   int64
+}
+
+// SpaceSaving is a mergeable sketch that tracks the most frequent elements with
+// at most capacity counters (the Space-Saving algorithm, a refinement of Misra-Gries).
+// Every element whose true frequency exceeds Total()/capacity is guaranteed to be monitored,
+// and each reported count overestimates the true frequency by at most its Error.
+type SpaceSaving[T comparable] struct {
+	capacity int
+	heap     []ssEntry[T] // min-heap by count
+	index    map[T]int    // value -> position in heap
+	total    int64
+}
+
+// NewSpaceSaving creates an empty SpaceSaving sketch with the given number of counters.
+// A capacity < 1 is treated as 1.
+func NewSpaceSaving[T comparable](capacity int) *SpaceSaving[T] {
+	capacity = max(capacity, 1)
+	return &SpaceSaving[T]{
+		capacity: capacity,
+		heap:     make([]ssEntry[T], 0, capacity),
+		index:    make(map[T]int, capacity),
+	}
+}
+
+// Capacity returns the number of counters of the sketch.
+func (s *SpaceSaving[T]) Capacity() int {
+	return s.capacity
+}
+
+// Total returns the number of elements added to the sketch.
+func (s *SpaceSaving[T]) Total() int64 {
+	return s.total
+}
+
+// Add records one occurrence of v.
+func (s *SpaceSaving[T]) Add(v T) {
+	s.total++
+	if i, ok := s.index[v]; ok {
+		s.heap[i].count++
+		s.down(i)
+		return
+	}
+	if len(s.heap) < s.capacity {
+		s.heap = append(s.heap, ssEntry[T]{value: v, count: 1})
+		s.index[v] = len(s.heap) - 1
+		s.up(len(s.heap) - 1)
+		return
+	}
+	// Replace the least frequent element; its count bounds the error of the newcomer
+	evicted := s.heap[0]
+	delete(s.index, evicted.value)
+	s.heap[0] = ssEntry[T]{value: v, count: evicted.count + 1, err: evicted.count}
+	s.index[v] = 0
+	s.down(0)
+}
+
+func (s *SpaceSaving[T]) swap(i, j int) {
+	s.heap[i], s.heap[j] = s.heap[j], s.heap[i]
+	s.index[s.heap[i].value] = i
+	s.index[s.heap[j].value] = j
+}
+
+func (s *SpaceSaving[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if s.heap[i].count >= s.heap[parent].count {
+			break
+		}
+		s.swap(i, parent)
+		i = parent
+	}
+}
+
+func (s *SpaceSaving[T]) down(i int) {
+	n := len(s.heap)
+	for {
+		smallest := i
+		left, right := 2*i+1, 2*i+2
+		if left < n && s.heap[left].count < s.heap[smallest].count {
+			smallest = left
+		}
+		if right < n && s.heap[right].count < s.heap[smallest].count {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		s.swap(i, smallest)
+		i = smallest
+	}
+}
+
+// minCount returns the count an unmonitored element may have had: the smallest
+// counter when the sketch is full, or zero otherwise.
+func (s *SpaceSaving[T]) minCount() int64 {
+	if len(s.heap) < s.capacity {
+		return 0
+	}
+	return s.heap[0].count
+}
+
+// Merge adds all elements summarized by other into s, keeping s's capacity.
+// Elements missing from a full sketch are charged that sketch's smallest count,
+// so the error bounds of the merged result remain valid.
+func (s *SpaceSaving[T]) Merge(other *SpaceSaving[T]) {
+	if other == nil || other.total == 0 {
+		return
+	}
+	minS, minO := s.minCount(), other.minCount()
+	merged := make([]ssEntry[T], 0, len(s.heap)+len(other.heap))
+	for _, e := range s.heap {
+		if j, ok := other.index[e.value]; ok {
+			e.count += other.heap[j].count
+			e.err += other.heap[j].err
+		} else {
+			e.count += minO
+			e.err += minO
+		}
+		merged = append(merged, e)
+	}
+	for _, e := range other.heap {
+		if _, ok := s.index[e.value]; !ok {
+			e.count += minS
+			e.err += minS
+			merged = append(merged, e)
+		}
+	}
+	slices.SortFunc(merged, func(a, b ssEntry[T]) int { return cmp.Compare(b.count, a.count) })
+	merged = merged[:min(len(merged), s.capacity)]
+
+	s.total += other.total
+	s.heap = s.heap[:0]
+	clear(s.index)
+	for _, e := range merged {
+		s.heap = append(s.heap, e)
+		s.index[e.value] = len(s.heap) - 1
+		s.up(len(s.heap) - 1)
+	}
+}
+
+// Top returns up to n monitored elements, most frequent first.
+// A non-positive n returns all monitored elements.
+func (s *SpaceSaving[T]) Top(n int) []HeavyHitter[T] {
+	result := make([]HeavyHitter[T], len(s.heap))
+	for i, e := range s.heap {
+		result[i] = HeavyHitter[T]{Value: e.value, Count: e.count, Error: e.err}
+	}
+	slices.SortStableFunc(result, func(a, b HeavyHitter[T]) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Error, b.Error)
+	})
+	if n > 0 && n < len(result) {
+		result = result[:n]
+	}
+	return result
+}
+
+// SpaceSavingCollector returns a Collector that builds a SpaceSaving sketch with the given capacity.
+func SpaceSavingCollector[T comparable](capacity int) Collector[T, *SpaceSaving[T], *SpaceSaving[T]] {
+	return Collector[T, *SpaceSaving[T], *SpaceSaving[T]]{
+		Supplier: func() *SpaceSaving[T] { return NewSpaceSaving[T](capacity) },
+		Accumulator: func(s *SpaceSaving[T], v T) *SpaceSaving[T] {
+			s.Add(v)
+			return s
+		},
+		Combiner: func(a, b *SpaceSaving[T]) *SpaceSaving[T] {
+			a.Merge(b)
+			return a
+		},
+		Finisher: func(s *SpaceSaving[T]) *SpaceSaving[T] { return s },
+	}
+}
+
+// HeavyHittersCollector returns a Collector that finds the n most frequent elements
+// in bounded memory, using a SpaceSaving sketch with the given capacity.
+// A larger capacity (e.g. 10*n) tightens the error bounds.
+func HeavyHittersCollector[T comparable](n, capacity int) Collector[T, *SpaceSaving[T], []HeavyHitter[T]] {
+	c := SpaceSavingCollector[T](max(capacity, n))
+	return Collector[T, *SpaceSaving[T], []HeavyHitter[T]]{
+		Supplier:    c.Supplier,
+		Accumulator: c.Accumulator,
+		Combiner:    c.Combiner,
+		Finisher: func(s *SpaceSaving[T]) []HeavyHitter[T] {
+			return s.Top(max(n, 0))
+		},
+	}
+}
+
+// ApproxMostCommon returns approximately the n most common elements with error bounds,
+// using a SpaceSaving sketch with the given capacity. Unlike MostCommon, memory is bounded
+// by capacity rather than the number of unique elements.
+func ApproxMostCommon[T comparable](s Stream[T], n, capacity int) []HeavyHitter[T] {
+	return CollectTo(s, HeavyHittersCollector[T](n, capacity))
+}
+
+// --- Count-Min Sketch (Approximate Frequencies) ---
+
+// CountMinSketch is a mergeable sketch answering point frequency queries in bounded memory.
+// Estimates never undercount; with probability 1-delta they overcount by at most
+// epsilon*Total(), where width = ceil(e/epsilon) and depth = ceil(ln(1/delta)).
+//
+// A sketch must be created with NewCountMinSketch or NewCountMinSketchWithSize, or filled
+// by a successful UnmarshalBinary, before values are added; the zero value estimates 0.
+type CountMinSketch[T any] struct {
+	width, depth int
+	counts       []int64 // depth rows of width counters
+	total        int64
+	hash         func(T) uint64
+}
+
+// NewCountMinSketch creates an empty CountMinSketch sized for the given error bounds.
+// Non-positive or out-of-range epsilon and delta default to 0.001 and 0.01.
+// If hash is nil, DefaultHash is used.
+func NewCountMinSketch[T any](epsilon, delta float64, hash func(T) uint64) *CountMinSketch[T] {
+	if epsilon <= 0 || epsilon >= 1 {
+		epsilon = 0.001
+	}
+	if delta <= 0 || delta >= 1 {
+		delta = 0.01
+	}
+	width := int(math.Ceil(math.E / epsilon))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+	return NewCountMinSketchWithSize(width, depth, hash)
+}
+
+// NewCountMinSketchWithSize creates an empty CountMinSketch with explicit dimensions.
+// Width and depth are at least 1. If hash is nil, DefaultHash is used.
+func NewCountMinSketchWithSize[T any](width, depth int, hash func(T) uint64) *CountMinSketch[T] {
+	width, depth = max(width, 1), max(depth, 1)
+	return &CountMinSketch[T]{
+		width:  width,
+		depth:  depth,
+		counts: make([]int64, width*depth),
+		hash:   hash,
+	}
+}
+
+// Width returns the number of counters per row.
+func (c *CountMinSketch[T]) Width() int {
+	return c.width
+}
+
+// Depth returns the number of rows.
+func (c *CountMinSketch[T]) Depth() int {
+	return c.depth
+}
+
+// Total returns the sum of all counts added.
+func (c *CountMinSketch[T]) Total() int64 {
+	return c.total
+}
+
+// cells calls fn with the counter index of v in each row.
+func (c *CountMinSketch[T]) cells(v T, fn func(int)) {
+	hash := c.hash
+	if hash == nil {
+		hash = DefaultHash[T]
+	}
+	// Double hashing: row i uses h1 + i*h2
+	h1 := hash(v)
+	h2 := mix64(h1) | 1
+	for i := range c.depth {
+		fn(i*c.width + int((h1+uint64(i)*h2)%uint64(c.width)))
+	}
+}
+
+// Add records one occurrence of v.
+func (c *CountMinSketch[T]) Add(v T) {
+	c.AddN(v, 1)
+}
+
+// AddN records n occurrences of v. Non-positive n is ignored.
+// It panics if the sketch has no counters (a zero value).
+func (c *CountMinSketch[T]) AddN(v T, n int64) {
+	if n <= 0 {
+		return
+	}
+	if c.depth == 0 {
+		panic("CountMinSketch.AddN: sketch is not initialized")
+	}
+	c.total += n
+	c.cells(v, func(i int) { c.counts[i] += n })
+}
+
+// Estimate returns the estimated frequency of v, which is never less than the true frequency.
+// A zero-value sketch estimates 0.
+func (c *CountMinSketch[T]) Estimate(v T) int64 {
+	if c.depth == 0 {
+		return 0
+	}
+	est := int64(math.MaxInt64)
+	c.cells(v, func(i int) { est = min(est, c.counts[i]) })
+	return est
+}
+
+// Merge adds all counts of other into c.
+// Returns ErrInvalidSketch if the dimensions differ. Both sketches must use the same hash.
+func (c *CountMinSketch[T]) Merge(other *CountMinSketch[T]) error {
+	if other == nil {
+		return nil
+	}
+	if other.width != c.width || other.depth != c.depth {
+		return ErrInvalidSketch
+	}
+	for i, n := range other.counts {
+		c.counts[i] += n
+	}
+	c.total += other.total
+	return nil
+}
+
+// MarshalBinary encodes the sketch so it can be stored and merged later.
+// The hash function is not encoded.
+func (c *CountMinSketch[T]) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 2+8*3+8*len(c.counts))
+	b = append(b, sketchVersion, sketchKindCountMin)
+	b = binary.BigEndian.AppendUint64(b, uint64(c.width))
+	b = binary.BigEndian.AppendUint64(b, uint64(c.depth))
+	b = binary.BigEndian.AppendUint64(b, uint64(c.total))
+	for _, n := range c.counts {
+		b = binary.BigEndian.AppendUint64(b, uint64(n))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes a sketch produced by MarshalBinary, replacing the receiver's
+// counters but keeping its hash function (DefaultHash for a zero value).
+func (c *CountMinSketch[T]) UnmarshalBinary(data []byte) error {
+	r := &sketchReader{data: data}
+	r.header(sketchKindCountMin)
+	width, depth, total := r.uint64(), r.uint64(), r.uint64()
+	if r.err != nil || width == 0 || depth == 0 || width > uint64(len(r.data)/8)/depth {
+		return ErrInvalidSketch
+	}
+	counts := make([]int64, width*depth)
+	for i := range counts {
+		counts[i] = int64(r.uint64())
+	}
+	if err := r.finish(); err != nil {
+		return err
+	}
+	c.width, c.depth, c.total, c.counts = int(width), int(depth), int64(total), counts
+	return nil
+}
+
+// CountMinSketchCollector returns a Collector that builds a CountMinSketch whose result
+// answers point frequency queries. If hash is nil, DefaultHash is used.
+func CountMinSketchCollector[T any](epsilon, delta float64, hash func(T) uint64) Collector[T, *CountMinSketch[T], *CountMinSketch[T]] {
+	return Collector[T, *CountMinSketch[T], *CountMinSketch[T]]{
+		Supplier: func() *CountMinSketch[T] { return NewCountMinSketch(epsilon, delta, hash) },
+		Accumulator: func(c *CountMinSketch[T], v T) *CountMinSketch[T] {
+			c.Add(v)
+			return c
+		},
+		Combiner: func(a, b *CountMinSketch[T]) *CountMinSketch[T] {
+			_ = a.Merge(b) // same dimensions by construction
+			return a
+		},
+		Finisher: func(c *CountMinSketch[T]) *CountMinSketch[T] { return c },
+	}
+}
+
+// --- Bloom Filters (Approximate Membership) ---
+
+// DefaultBloomFalsePositiveRate is the false-positive rate used when an invalid rate is given.
+const DefaultBloomFalsePositiveRate = 0.01
+
+// scalableBloomTightening is the ratio between the false-positive rates of
+// consecutive filters in a ScalableBloomFilter.
+const scalableBloomTightening = 0.85
+
+// BloomFilter is a mergeable set-membership sketch. Contains never returns false for
+// an added value, and returns true for a value that was not added with a probability
+// close to the target false-positive rate as long as no more than the expected number
+// of values are added.
+//
+// A filter must be created with NewBloomFilter or filled by a successful UnmarshalBinary
+// before values are added; the zero value is an empty filter that contains nothing.
+type BloomFilter[T any] struct {
+	bits []uint64
+	m    uint64 // number of bits
+	k    int    // number of hash functions
+	hash func(T) uint64
+}
+
+// NewBloomFilter creates an empty BloomFilter sized for the expected number of values
+// and target false-positive rate. A non-positive expected is treated as 1 and an
+// invalid rate uses DefaultBloomFalsePositiveRate. If hash is nil, DefaultHash is used.
+func NewBloomFilter[T any](expected int, fpRate float64, hash func(T) uint64) *BloomFilter[T] {
+	if fpRate <= 0 || fpRate >= 1 {
+		fpRate = DefaultBloomFalsePositiveRate
+	}
+	n := float64(max(expected, 1))
+	m := max(uint64(math.Ceil(-n*math.Log(fpRate)/(math.Ln2*math.Ln2))), 64)
+	k := max(int(math.Round(float64(m)/n*math.Ln2)), 1)
+	return &BloomFilter[T]{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+		hash: hash,
+	}
+}
+
+// BitSize returns the number of bits of the filter.
+func (b *BloomFilter[T]) BitSize() int {
+	return int(b.m)
+}
+
+// HashCount returns the number of hash functions of the filter.
+func (b *BloomFilter[T]) HashCount() int {
+	return b.k
+}
+
+// hashes returns the two base hashes of v used for double hashing.
+func (b *BloomFilter[T]) hashes(v T) (uint64, uint64) {
+	hash := b.hash
+	if hash == nil {
+		hash = DefaultHash[T]
+	}
+	h1 := hash(v)
+	return h1, mix64(h1) | 1
+}
+
+// Add adds v to the filter. It panics if the filter has no bits (a zero value).
+func (b *BloomFilter[T]) Add(v T) {
+	if b.k == 0 {
+		panic("BloomFilter.Add: filter is not initialized")
+	}
+	h1, h2 := b.hashes(v)
+	for i := range b.k {
+		bit := (h1 + uint64(i)*h2) % b.m
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// Contains reports whether v may have been added.
+// False means v was definitely not added.
+func (b *BloomFilter[T]) Contains(v T) bool {
+	if b.k == 0 {
+		return false
+	}
+	h1, h2 := b.hashes(v)
+	for i := range b.k {
+		bit := (h1 + uint64(i)*h2) % b.m
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Merge adds all values of other into b (set union).
+// Returns ErrInvalidSketch if the sizes differ. Both filters must use the same hash.
+func (b *BloomFilter[T]) Merge(other *BloomFilter[T]) error {
+	if other == nil {
+		return nil
+	}
+	if other.m != b.m || other.k != b.k {
+		return ErrInvalidSketch
+	}
+	for i, w := range other.bits {
+		b.bits[i] |= w
+	}
+	return nil
+}
+
+// MarshalBinary encodes the filter so it can be stored and used later.
+// The hash function is not encoded.
+func (b *BloomFilter[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 2+8*2+8*len(b.bits))
+	data = append(data, sketchVersion, sketchKindBloomFilter)
+	data = binary.BigEndian.AppendUint64(data, b.m)
+	data = binary.BigEndian.AppendUint64(data, uint64(b.k))
+	for _, w := range b.bits {
+		data = binary.BigEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a filter produced by MarshalBinary, replacing the receiver's
+// bits but keeping its hash function (DefaultHash for a zero value). On error the
+// receiver is unchanged, so a zero value stays empty and must not be used for Add.
+func (b *BloomFilter[T]) UnmarshalBinary(data []byte) error {
+	r := &sketchReader{data: data}
+	r.header(sketchKindBloomFilter)
+	m, k := r.uint64(), r.uint64()
+	if r.err != nil || m == 0 || k == 0 || k > 64 || (m+63)/64 != uint64(len(r.data)/8) {
+		return ErrInvalidSketch
+	}
+	words := make([]uint64, (m+63)/64)
+	for i := range words {
+		words[i] = r.uint64()
+	}
+	if err := r.finish(); err != nil {
+		return err
+	}
+	b.bits, b.m, b.k = words, m, int(k)
+	return nil
+}
+
+// BloomFilterCollector returns a Collector that builds a BloomFilter from elements,
+// e.g. to cheaply pre-filter another stream with BloomSemiJoinBy.
+// If hash is nil, DefaultHash is used.
+func BloomFilterCollector[T any](expected int, fpRate float64, hash func(T) uint64) Collector[T, *BloomFilter[T], *BloomFilter[T]] {
+	return Collector[T, *BloomFilter[T], *BloomFilter[T]]{
+		Supplier: func() *BloomFilter[T] { return NewBloomFilter(expected, fpRate, hash) },
+		Accumulator: func(b *BloomFilter[T], v T) *BloomFilter[T] {
+			b.Add(v)
+			return b
+		},
+		Combiner: func(a, b *BloomFilter[T]) *BloomFilter[T] {
+			_ = a.Merge(b) // same size by construction
+			return a
+		},
+		Finisher: func(b *BloomFilter[T]) *BloomFilter[T] { return b },
+	}
+}
+
+// BloomSemiJoinBy returns elements of s whose key may be contained in filter.
+// Elements whose key was added to the filter are always kept; others are dropped
+// except for occasional false positives. It is a cheap pre-filter before an exact join.
+func BloomSemiJoinBy[T, K any](s Stream[T], filter *BloomFilter[K], keyFn func(T) K) Stream[T] {
+	return s.Filter(func(v T) bool { return filter.Contains(keyFn(v)) })
+}
+
+// ScalableBloomFilter is a Bloom filter that grows as values are added while keeping
+// the overall false-positive rate below its target. It chains filters of doubling
+// capacity with geometrically tightening false-positive rates.
+//
+// Each new filter's rate is 0.85 times the previous one, so the newest filter needs
+// about a third of a bit more per value with every doubling of the distinct count.
+type ScalableBloomFilter[T any] struct {
+	filters  []*BloomFilter[T]
+	capacity int     // capacity of the newest filter
+	fpRate   float64 // false-positive rate of the newest filter
+	fill     int     // values added to the newest filter
+	hash     func(T) uint64
+}
+
+// NewScalableBloomFilter creates an empty ScalableBloomFilter with the given initial capacity
+// and overall target false-positive rate. If hash is nil, DefaultHash is used.
+func NewScalableBloomFilter[T any](initialCapacity int, fpRate float64, hash func(T) uint64) *ScalableBloomFilter[T] {
+	if fpRate <= 0 || fpRate >= 1 {
+		fpRate = DefaultBloomFalsePositiveRate
+	}
+	capacity := max(initialCapacity, 1)
+	// Rates tighten by r per filter, so the series p(1-r) * (1 + r + r^2 + ...) stays below p
+	rate := fpRate * (1 - scalableBloomTightening)
+	return &ScalableBloomFilter[T]{
+		filters:  []*BloomFilter[T]{NewBloomFilter(capacity, rate, hash)},
+		capacity: capacity,
+		fpRate:   rate,
+		hash:     hash,
+	}
+}
+
+// Contains reports whether v may have been added.
+// False means v was definitely not added.
+func (s *ScalableBloomFilter[T]) Contains(v T) bool {
+	for _, f := range s.filters {
+		if f.Contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Add adds v to the filter. It returns false if v may already have been added,
+// in which case the filter is unchanged.
+func (s *ScalableBloomFilter[T]) Add(v T) bool {
+	if s.Contains(v) {
+		return false
+	}
+	if s.fill >= s.capacity {
+		s.capacity *= 2
+		s.fpRate *= scalableBloomTightening
+		s.filters = append(s.filters, NewBloomFilter(s.capacity, s.fpRate, s.hash))
+		s.fill = 0
+	}
+	s.filters[len(s.filters)-1].Add(v)
+	s.fill++
+	return true
+}
+
+// DistinctApprox returns a Stream with duplicates removed using a ScalableBloomFilter
+// instead of an exact set, so memory grows with about 14 bits per distinct element
+// (at 1% false positives) rather than with the elements themselves. Bits per element
+// grow slowly as the filter scales, to about 16 after a million distinct elements.
+// Duplicates are never emitted, but with probability about fpRate a distinct element
+// is mistaken for a duplicate and dropped. Elements are hashed with DefaultHash.
+func DistinctApprox[T any](s Stream[T], fpRate float64) Stream[T] {
+	return DistinctApproxBy(s, func(v T) T { return v }, fpRate)
+}
+
+// DistinctApproxBy is like DistinctApprox but compares elements by a key.
+// Keys are hashed with DefaultHash; return a uint64 hash from keyFn to use a custom hash.
+func DistinctApproxBy[T, K any](s Stream[T], keyFn func(T) K, fpRate float64) Stream[T] {
+	return Stream[T]{
+		seq: func(yield func(T) bool) {
+			seen := NewScalableBloomFilter[K](1024, fpRate, nil)
+			for v := range s.seq {
+				if seen.Add(keyFn(v)) && !yield(v) {
+					return
+				}
+			}
+		},
+	}
+}
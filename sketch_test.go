@@ -0,0 +1,701 @@
+package streams
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- TDigest Tests ---
+
+func TestTDigest(t *testing.T) {
+	t.Parallel()
+	t.Run("EmptyDigest", func(t *testing.T) {
+		t.Parallel()
+		d := NewTDigest(0)
+		assert.Equal(t, float64(DefaultTDigestCompression), d.Compression(), "Non-positive compression should use the default")
+		assert.Equal(t, int64(0), d.Count(), "Empty digest should have zero count")
+		assert.True(t, d.Quantile(0.5).IsEmpty(), "Empty digest quantile should be None")
+		assert.True(t, d.Min().IsEmpty(), "Empty digest Min should be None")
+		assert.True(t, d.Quantiles(0.5, 0.9).IsEmpty(), "Empty digest Quantiles should be None")
+	})
+
+	t.Run("ZeroValue", func(t *testing.T) {
+		t.Parallel()
+		var d TDigest
+		assert.Equal(t, float64(DefaultTDigestCompression), d.Compression(), "Zero value should use the default compression")
+		for i := 10; i <= 20; i++ {
+			d.Add(float64(i))
+		}
+		assert.Equal(t, 10.0, d.Min().Get(), "Zero value Min should be the smallest value")
+		assert.Equal(t, 20.0, d.Max().Get(), "Zero value Max should be the largest value")
+		assert.InDelta(t, 15, d.Quantile(0.5).Get(), 0.5, "Zero value median should be accurate")
+
+		var merged TDigest
+		merged.Merge(&d)
+		assert.Equal(t, 10.0, merged.Min().Get(), "Merging into a zero value should keep Min")
+		assert.Equal(t, 20.0, merged.Max().Get(), "Merging into a zero value should keep Max")
+	})
+
+	t.Run("SingleValue", func(t *testing.T) {
+		t.Parallel()
+		d := NewTDigest(100)
+		d.Add(42)
+		assert.Equal(t, 42.0, d.Quantile(0.5).Get(), "Single value digest should return the value")
+		assert.Equal(t, 42.0, d.Quantile(0).Get(), "Quantile 0 should be the min")
+		assert.Equal(t, 42.0, d.Quantile(1).Get(), "Quantile 1 should be the max")
+	})
+
+	t.Run("AccuracyOnUniformData", func(t *testing.T) {
+		t.Parallel()
+		rng := rand.New(rand.NewPCG(1, 2))
+		d := NewTDigest(100)
+		const n = 100_000
+		for range n {
+			d.Add(rng.Float64() * 1000)
+		}
+
+		assert.Equal(t, int64(n), d.Count(), "Count should match number of values")
+		assert.InDelta(t, 500, d.Quantile(0.5).Get(), 10, "Median should be within 1% of range")
+		assert.InDelta(t, 990, d.Quantile(0.99).Get(), 2, "p99 should be within 0.2% of range")
+		assert.InDelta(t, 999, d.Quantile(0.999).Get(), 1, "p99.9 should be within 0.1% of range")
+		assert.Less(t, len(d.centroids), 250, "Centroid count should be bounded by compression")
+	})
+
+	t.Run("SmallDataNearExact", func(t *testing.T) {
+		t.Parallel()
+		d := NewTDigest(100)
+		for i := 1; i <= 11; i++ {
+			d.Add(float64(i))
+		}
+		assert.InDelta(t, 6, d.Quantile(0.5).Get(), 0.5, "Median of 1..11 should be about 6")
+		assert.Equal(t, 1.0, d.Min().Get(), "Min should be exact")
+		assert.Equal(t, 11.0, d.Max().Get(), "Max should be exact")
+	})
+
+	t.Run("NaNIgnored", func(t *testing.T) {
+		t.Parallel()
+		d := NewTDigest(100)
+		d.Add(math.NaN())
+		assert.Equal(t, int64(0), d.Count(), "NaN should be ignored")
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		t.Parallel()
+		a, b := NewTDigest(100), NewTDigest(100)
+		for i := range 50_000 {
+			a.Add(float64(i))
+			b.Add(float64(i + 50_000))
+		}
+		a.Merge(b)
+		a.Merge(nil)
+		a.Merge(NewTDigest(100))
+
+		assert.Equal(t, int64(100_000), a.Count(), "Merged count should be the sum")
+		assert.InDelta(t, 50_000, a.Quantile(0.5).Get(), 500, "Merged median should be accurate")
+		assert.InDelta(t, 99_000, a.Quantile(0.99).Get(), 200, "Merged p99 should be accurate")
+		assert.Equal(t, 0.0, a.Min().Get(), "Merged Min should be exact")
+		assert.Equal(t, 99_999.0, a.Max().Get(), "Merged Max should be exact")
+	})
+
+	t.Run("MarshalRoundTrip", func(t *testing.T) {
+		t.Parallel()
+		d := NewTDigest(50)
+		for i := range 10_000 {
+			d.Add(float64(i))
+		}
+		data, err := d.MarshalBinary()
+		require.NoError(t, err, "MarshalBinary should succeed")
+
+		var decoded TDigest
+		require.NoError(t, decoded.UnmarshalBinary(data), "UnmarshalBinary should succeed")
+		assert.Equal(t, d.Count(), decoded.Count(), "Decoded count should match")
+		assert.Equal(t, d.Compression(), decoded.Compression(), "Decoded compression should match")
+		assert.Equal(t, d.Quantile(0.9).Get(), decoded.Quantile(0.9).Get(), "Decoded quantiles should match")
+
+		decoded.Add(20_000)
+		assert.Equal(t, int64(10_001), decoded.Count(), "Decoded digest should accept new values")
+	})
+
+	t.Run("UnmarshalInvalid", func(t *testing.T) {
+		t.Parallel()
+		var d TDigest
+		assert.ErrorIs(t, d.UnmarshalBinary(nil), ErrInvalidSketch, "Empty data should be invalid")
+		assert.ErrorIs(t, d.UnmarshalBinary([]byte{sketchVersion, 99}), ErrInvalidSketch, "Unknown kind should be invalid")
+
+		valid, _ := NewTDigest(10).MarshalBinary()
+		assert.ErrorIs(t, d.UnmarshalBinary(valid[:len(valid)-1]), ErrInvalidSketch, "Truncated data should be invalid")
+		assert.ErrorIs(t, d.UnmarshalBinary(append(valid, 0)), ErrInvalidSketch, "Trailing data should be invalid")
+
+		encode := func(count, lo, hi float64, cs ...centroid) []byte {
+			b := []byte{sketchVersion, sketchKindTDigest}
+			b = appendFloat64(b, 10)
+			b = appendFloat64(b, count)
+			b = appendFloat64(b, lo)
+			b = appendFloat64(b, hi)
+			b = binary.BigEndian.AppendUint64(b, uint64(len(cs)))
+			for _, c := range cs {
+				b = appendFloat64(b, c.mean)
+				b = appendFloat64(b, c.weight)
+			}
+			return b
+		}
+		require.NoError(t, d.UnmarshalBinary(encode(3, 1, 5, centroid{1, 1}, centroid{3, 2})), "A consistent payload should decode")
+		assert.ErrorIs(t, d.UnmarshalBinary(encode(5, 0, 1)), ErrInvalidSketch, "A count without centroids should be invalid")
+		assert.ErrorIs(t, d.UnmarshalBinary(encode(4, 1, 5, centroid{1, 1}, centroid{3, 2})), ErrInvalidSketch, "Weights not summing to count should be invalid")
+		assert.ErrorIs(t, d.UnmarshalBinary(encode(1, 1, 5, centroid{1, 2}, centroid{3, -1})), ErrInvalidSketch, "Negative weights should be invalid")
+		assert.ErrorIs(t, d.UnmarshalBinary(encode(1, 1, 5, centroid{1, math.Inf(1)})), ErrInvalidSketch, "Infinite weights should be invalid")
+		assert.ErrorIs(t, d.UnmarshalBinary(encode(3, 1, 5, centroid{3, 1}, centroid{1, 2})), ErrInvalidSketch, "Unsorted means should be invalid")
+		assert.ErrorIs(t, d.UnmarshalBinary(encode(3, 1, 5, centroid{1, 1}, centroid{9, 2})), ErrInvalidSketch, "Means outside [min, max] should be invalid")
+		assert.Equal(t, int64(3), d.Count(), "A rejected payload should leave the digest unchanged")
+		assert.NotPanics(t, func() { d.Quantile(0.5) })
+	})
+}
+
+func TestApproxQuantileCollector(t *testing.T) {
+	t.Parallel()
+	t.Run("MultipleQuantilesOnePass", func(t *testing.T) {
+		t.Parallel()
+		result := CollectTo(Range(0, 10_001), ApproxQuantileCollector[int](200, 0.5, 0.9, 0.99))
+
+		require.True(t, result.IsPresent(), "ApproxQuantileCollector should return quantiles")
+		qs := result.Get()
+		assert.InDelta(t, 5000, qs[0], 50, "Median should be accurate")
+		assert.InDelta(t, 9000, qs[1], 50, "p90 should be accurate")
+		assert.InDelta(t, 9900, qs[2], 20, "p99 should be accurate")
+	})
+
+	t.Run("EmptyStream", func(t *testing.T) {
+		t.Parallel()
+		result := ApproxQuantiles(Empty[float64](), 100, 0.5)
+		assert.True(t, result.IsEmpty(), "ApproxQuantiles on empty stream should be None")
+	})
+
+	t.Run("ParallelCollect", func(t *testing.T) {
+		t.Parallel()
+		d := ParallelCollectTo(Range(0, 100_000), TDigestCollector[int](100), WithConcurrency(4))
+
+		assert.Equal(t, int64(100_000), d.Count(), "Parallel digest should see all values")
+		assert.InDelta(t, 50_000, d.Quantile(0.5).Get(), 1000, "Parallel digest median should be accurate")
+	})
+}
+
+// --- HyperLogLog Tests ---
+
+func TestDefaultHash(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, DefaultHash("abc"), DefaultHash("abc"), "DefaultHash should be deterministic")
+	assert.Equal(t, DefaultHash("abc"), DefaultHash([]byte("abc")), "Strings and byte slices should hash alike")
+	assert.NotEqual(t, DefaultHash(1), DefaultHash(2), "Different ints should hash differently")
+	assert.Equal(t, DefaultHash(0.0), DefaultHash(math.Copysign(0, -1)), "0 and -0 should hash alike")
+	assert.NotEqual(t, DefaultHash(true), DefaultHash(false), "Booleans should hash differently")
+	type point struct{ X, Y int }
+	assert.Equal(t, DefaultHash(point{1, 2}), DefaultHash(point{1, 2}), "Other types should hash by value")
+	assert.NotEqual(t, DefaultHash(point{1, 2}), DefaultHash(point{2, 1}), "Other types should hash by value")
+}
+
+func TestHyperLogLog(t *testing.T) {
+	t.Parallel()
+	t.Run("PrecisionBounds", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, DefaultHyperLogLogPrecision, NewHyperLogLog(0).Precision(), "Non-positive precision should use the default")
+		assert.Equal(t, MinHyperLogLogPrecision, NewHyperLogLog(1).Precision(), "Small precision should be clamped")
+		assert.Equal(t, MaxHyperLogLogPrecision, NewHyperLogLog(30).Precision(), "Large precision should be clamped")
+	})
+
+	t.Run("EmptyAndSmall", func(t *testing.T) {
+		t.Parallel()
+		h := NewHyperLogLog(14)
+		assert.Equal(t, int64(0), h.Estimate(), "Empty sketch should estimate zero")
+		for i := range 100 {
+			h.AddHash(DefaultHash(i))
+			h.AddHash(DefaultHash(i))
+		}
+		assert.Equal(t, int64(100), h.Estimate(), "Small cardinalities should be exact in sparse mode")
+	})
+
+	t.Run("ZeroValue", func(t *testing.T) {
+		t.Parallel()
+		var h HyperLogLog
+		assert.Equal(t, int64(0), h.Estimate(), "Zero value should estimate zero")
+		for i := range 100 {
+			h.AddHash(DefaultHash(i))
+		}
+		assert.Equal(t, DefaultHyperLogLogPrecision, h.Precision(), "Zero value should use the default precision")
+		assert.Equal(t, int64(100), h.Estimate(), "Zero value should count like a new sketch")
+
+		var merged HyperLogLog
+		require.NoError(t, merged.Merge(&h), "Merging into a zero value should succeed")
+		require.NoError(t, merged.Merge(&HyperLogLog{}), "Merging a zero value should be a no-op")
+		assert.Equal(t, int64(100), merged.Estimate())
+	})
+
+	t.Run("AccuracyAcrossRanges", func(t *testing.T) {
+		t.Parallel()
+		for _, p := range []int{10, 14} {
+			h := NewHyperLogLog(p)
+			next := 0
+			for _, n := range []int{1_000, 10_000, 200_000} {
+				for ; next < n; next++ {
+					h.AddHash(DefaultHash(next))
+				}
+				tolerance := 4 * 1.04 / math.Sqrt(float64(int(1)<<p))
+				assert.InEpsilon(t, n, h.Estimate(), tolerance, "Estimate at p=%d n=%d should be within 4 standard errors", p, n)
+			}
+		}
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		t.Parallel()
+		a, b, sparse := NewHyperLogLog(12), NewHyperLogLog(12), NewHyperLogLog(12)
+		for i := range 50_000 {
+			a.AddHash(DefaultHash(i))
+			b.AddHash(DefaultHash(i + 25_000))
+		}
+		for i := range 10 {
+			sparse.AddHash(DefaultHash(-i - 1))
+		}
+		require.NoError(t, a.Merge(b), "Merge with same precision should succeed")
+		require.NoError(t, a.Merge(sparse), "Merge with sparse sketch should succeed")
+		require.NoError(t, a.Merge(nil), "Merge with nil should be a no-op")
+		assert.InEpsilon(t, 75_010, a.Estimate(), 0.05, "Merged estimate should count the union")
+
+		assert.ErrorIs(t, a.Merge(NewHyperLogLog(10)), ErrInvalidSketch, "Merge with different precision should fail")
+	})
+
+	t.Run("SparseMergeIntoDense", func(t *testing.T) {
+		t.Parallel()
+		sparse := NewHyperLogLog(8)
+		for i := range 20 {
+			sparse.AddHash(DefaultHash(i))
+		}
+		dense := NewHyperLogLog(8)
+		for i := range 10_000 {
+			dense.AddHash(DefaultHash(i))
+		}
+		before := dense.Estimate()
+		require.NoError(t, sparse.Merge(dense), "Merge should succeed")
+		assert.Equal(t, before, sparse.Estimate(), "Merging a subset should match the superset")
+	})
+
+	t.Run("MarshalRoundTrip", func(t *testing.T) {
+		t.Parallel()
+		for _, n := range []int{10, 10_000} {
+			h := NewHyperLogLog(10)
+			for i := range n {
+				h.AddHash(DefaultHash(i))
+			}
+			data, err := h.MarshalBinary()
+			require.NoError(t, err, "MarshalBinary should succeed")
+
+			var decoded HyperLogLog
+			require.NoError(t, decoded.UnmarshalBinary(data), "UnmarshalBinary should succeed")
+			assert.Equal(t, h.Estimate(), decoded.Estimate(), "Decoded estimate should match for n=%d", n)
+			assert.Equal(t, 10, decoded.Precision(), "Decoded precision should match")
+			decoded.AddHash(DefaultHash(-1))
+		}
+	})
+
+	t.Run("UnmarshalInvalid", func(t *testing.T) {
+		t.Parallel()
+		var h HyperLogLog
+		assert.ErrorIs(t, h.UnmarshalBinary([]byte{sketchVersion, sketchKindHyperLogLog, 40, 0}), ErrInvalidSketch, "Bad precision should be invalid")
+		assert.ErrorIs(t, h.UnmarshalBinary([]byte{sketchVersion, sketchKindHyperLogLog, 4, 1, 0}), ErrInvalidSketch, "Short registers should be invalid")
+		assert.ErrorIs(t, h.UnmarshalBinary([]byte{sketchVersion, sketchKindHyperLogLog, 4, 2}), ErrInvalidSketch, "Unknown mode should be invalid")
+		tdigest, _ := NewTDigest(10).MarshalBinary()
+		assert.ErrorIs(t, h.UnmarshalBinary(tdigest), ErrInvalidSketch, "Other sketch kinds should be invalid")
+	})
+
+	t.Run("UnmarshalCorrupt", func(t *testing.T) {
+		t.Parallel()
+		dense := NewHyperLogLog(4)
+		for i := range 1000 {
+			dense.AddHash(DefaultHash(i))
+		}
+		data, err := dense.MarshalBinary()
+		require.NoError(t, err, "MarshalBinary should succeed")
+		data[len(data)-1] = 62
+		var h HyperLogLog
+		assert.ErrorIs(t, h.UnmarshalBinary(data), ErrInvalidSketch, "Dense rank above 65-p should be invalid")
+		data[len(data)-1] = 61
+		require.NoError(t, h.UnmarshalBinary(data), "Dense rank of 65-p should be valid")
+		assert.Positive(t, h.Estimate(), "Decoded sketch should be usable")
+
+		sparse := func(idx uint32, rank byte) []byte {
+			b := []byte{sketchVersion, sketchKindHyperLogLog, 14, 0, 0, 0, 0, 0, 0, 0, 0, 1}
+			return append(binary.BigEndian.AppendUint32(b, idx), rank)
+		}
+		assert.ErrorIs(t, h.UnmarshalBinary(sparse(1<<25, 1)), ErrInvalidSketch, "Sparse index out of range should be invalid")
+		assert.ErrorIs(t, h.UnmarshalBinary(sparse(1<<25-1, 41)), ErrInvalidSketch, "Sparse rank above 40 should be invalid")
+		require.NoError(t, h.UnmarshalBinary(sparse(1<<25-1, 40)), "Maximal sparse entry should be valid")
+		assert.Equal(t, int64(1), h.Estimate(), "Decoded sparse sketch should be usable")
+		assert.NoError(t, NewHyperLogLog(14).Merge(&h), "Decoded sparse sketch should merge")
+		h.toDense()
+		assert.Positive(t, h.Estimate(), "Decoded sparse sketch should convert to dense")
+	})
+}
+
+func TestApproxDistinctCountCollector(t *testing.T) {
+	t.Parallel()
+	t.Run("DefaultHash", func(t *testing.T) {
+		t.Parallel()
+		words := Range(0, 30_000).Map(func(i int) int { return i % 20_000 })
+		result := CollectTo(words, ApproxDistinctCountCollector[int](14, nil))
+		assert.InEpsilon(t, 20_000, result, 0.03, "Estimate should be close to the distinct count")
+	})
+
+	t.Run("CustomHash", func(t *testing.T) {
+		t.Parallel()
+		type user struct{ ID int }
+		users := Of(user{1}, user{2}, user{1}, user{3})
+		result := CollectTo(users, ApproxDistinctCountCollector(0, func(u user) uint64 { return DefaultHash(u.ID) }))
+		assert.Equal(t, int64(3), result, "Custom hash should be used")
+	})
+
+	t.Run("ApproxDistinctCount", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, int64(3), ApproxDistinctCount(Of("a", "b", "a", "c"), 0), "Small streams should be exact")
+		assert.Equal(t, int64(0), ApproxDistinctCount(Empty[string](), 0), "Empty stream should estimate zero")
+	})
+
+	t.Run("Parallel", func(t *testing.T) {
+		t.Parallel()
+		result := ParallelCollectTo(Range(0, 100_000), ApproxDistinctCountCollector[int](14, nil), WithConcurrency(4))
+		assert.InEpsilon(t, 100_000, result, 0.03, "Parallel estimate should merge partial sketches")
+	})
+
+	t.Run("ByKey", func(t *testing.T) {
+		t.Parallel()
+		type visit struct {
+			Page string
+			User int
+		}
+		c := ApproxDistinctCountByKeyCollector(
+			func(v visit) string { return v.Page },
+			func(v visit) int { return v.User },
+			0, nil,
+		)
+		events := Of(visit{"home", 1}, visit{"home", 2}, visit{"home", 1}, visit{"about", 3})
+		assert.Equal(t, map[string]int64{"home": 2, "about": 1}, CollectTo(events, c), "Distinct users per page should be counted")
+	})
+
+	t.Run("ByKeyStream2", func(t *testing.T) {
+		t.Parallel()
+		s := PairsOf(NewPair("a", 1), NewPair("a", 2), NewPair("b", 1), NewPair("a", 1))
+		assert.Equal(t, map[string]int64{"a": 2, "b": 1}, ApproxDistinctCountByKey(s, 0), "Distinct values per key should be counted")
+	})
+}
+
+// --- Heavy Hitters Tests ---
+
+// zipfStream returns a deterministic skewed stream where value i appears about n/(i+1) times.
+func zipfStream(n int) Stream[int] {
+	rng := rand.New(rand.NewPCG(3, 4))
+	z := rand.NewZipf(rng, 1.2, 1, 10_000)
+	return Generate(func() int { return int(z.Uint64()) }).Limit(n)
+}
+
+func TestSpaceSaving(t *testing.T) {
+	t.Parallel()
+	t.Run("ExactWhenUnderCapacity", func(t *testing.T) {
+		t.Parallel()
+		s := NewSpaceSaving[string](10)
+		for _, v := range []string{"a", "b", "a", "c", "a", "b"} {
+			s.Add(v)
+		}
+		assert.Equal(t, []HeavyHitter[string]{
+			{Value: "a", Count: 3},
+			{Value: "b", Count: 2},
+			{Value: "c", Count: 1},
+		}, s.Top(0), "Counts should be exact when under capacity")
+		assert.Equal(t, int64(6), s.Total(), "Total should count all elements")
+		assert.Len(t, s.Top(2), 2, "Top should limit results")
+	})
+
+	t.Run("ErrorBoundsOnSkewedData", func(t *testing.T) {
+		t.Parallel()
+		const n = 100_000
+		exact := Frequency(zipfStream(n))
+		s := CollectTo(zipfStream(n), SpaceSavingCollector[int](100))
+
+		assert.Equal(t, 100, s.Capacity(), "Capacity should match")
+		for _, h := range s.Top(10) {
+			truth := int64(exact[h.Value])
+			assert.GreaterOrEqual(t, h.Count, truth, "Count should be an upper bound for %d", h.Value)
+			assert.LessOrEqual(t, h.Count-h.Error, truth, "Count-Error should be a lower bound for %d", h.Value)
+			assert.LessOrEqual(t, h.Error, int64(n/100), "Error should be at most Total/capacity")
+		}
+		top := s.Top(3)
+		assert.Equal(t, []int{0, 1, 2}, []int{top[0].Value, top[1].Value, top[2].Value}, "Most frequent elements should be found")
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		t.Parallel()
+		a, b := NewSpaceSaving[int](3), NewSpaceSaving[int](3)
+		for _, v := range []int{1, 1, 1, 2, 2, 3, 4} {
+			a.Add(v)
+		}
+		for _, v := range []int{1, 5, 5, 5, 5} {
+			b.Add(v)
+		}
+		a.Merge(b)
+		a.Merge(nil)
+
+		assert.Equal(t, int64(12), a.Total(), "Merged total should be the sum")
+		top := a.Top(2)
+		assert.Equal(t, 5, top[0].Value, "Most frequent element after merge")
+		assert.Equal(t, 1, top[1].Value, "Second most frequent element after merge")
+		assert.GreaterOrEqual(t, top[1].Count, int64(4), "Merged count should be an upper bound")
+		assert.LessOrEqual(t, top[1].Count-top[1].Error, int64(4), "Merged lower bound should hold")
+		assert.Len(t, a.Top(0), 3, "Merge should keep capacity")
+	})
+
+	t.Run("ParallelMatchesSequentialTop", func(t *testing.T) {
+		t.Parallel()
+		result := ParallelCollectTo(zipfStream(50_000), HeavyHittersCollector[int](3, 200), WithConcurrency(4))
+		require.Len(t, result, 3, "Parallel heavy hitters should return n results")
+		assert.Equal(t, 0, result[0].Value, "Parallel heavy hitters should find the most common element")
+	})
+
+	t.Run("ApproxMostCommon", func(t *testing.T) {
+		t.Parallel()
+		result := ApproxMostCommon(Of("x", "y", "x", "z", "x", "y"), 2, 10)
+		assert.Equal(t, []HeavyHitter[string]{{Value: "x", Count: 3}, {Value: "y", Count: 2}}, result, "ApproxMostCommon should match MostCommon for small inputs")
+		assert.Empty(t, ApproxMostCommon(Empty[string](), 2, 10), "Empty stream should have no heavy hitters")
+	})
+}
+
+func TestCountMinSketch(t *testing.T) {
+	t.Parallel()
+	t.Run("Dimensions", func(t *testing.T) {
+		t.Parallel()
+		c := NewCountMinSketch[int](0.01, 0.01, nil)
+		assert.Equal(t, 272, c.Width(), "Width should be ceil(e/epsilon)")
+		assert.Equal(t, 5, c.Depth(), "Depth should be ceil(ln(1/delta))")
+		d := NewCountMinSketch[int](0, 2, nil)
+		assert.Equal(t, 2719, d.Width(), "Invalid epsilon should use the default")
+	})
+
+	t.Run("PointQueries", func(t *testing.T) {
+		t.Parallel()
+		const n = 100_000
+		exact := Frequency(zipfStream(n))
+		c := CollectTo(zipfStream(n), CountMinSketchCollector[int](0.001, 0.01, nil))
+
+		assert.Equal(t, int64(n), c.Total(), "Total should count all elements")
+		for v, truth := range exact {
+			est := c.Estimate(v)
+			assert.GreaterOrEqual(t, est, int64(truth), "Estimate should never undercount %d", v)
+		}
+		assert.LessOrEqual(t, c.Estimate(0)-int64(exact[0]), int64(0.001*n), "Overcount should be within epsilon*Total")
+		assert.LessOrEqual(t, c.Estimate(-1), int64(0.001*n), "Unseen values should be within epsilon*Total")
+	})
+
+	t.Run("AddNAndCustomHash", func(t *testing.T) {
+		t.Parallel()
+		c := NewCountMinSketchWithSize(64, 3, func(s string) uint64 { return DefaultHash(len(s)) })
+		c.AddN("ab", 5)
+		c.AddN("cd", 0)
+		c.Add("xy")
+		assert.Equal(t, int64(6), c.Estimate("ab"), "Custom hash should collide equal-length strings")
+		assert.Equal(t, int64(6), c.Total(), "Total should include AddN counts")
+	})
+
+	t.Run("MergeAndParallel", func(t *testing.T) {
+		t.Parallel()
+		a := NewCountMinSketch[string](0.01, 0.01, nil)
+		b := NewCountMinSketch[string](0.01, 0.01, nil)
+		a.AddN("k", 3)
+		b.AddN("k", 4)
+		require.NoError(t, a.Merge(b), "Merge should succeed")
+		assert.Equal(t, int64(7), a.Estimate("k"), "Merged estimate should add counts")
+		assert.ErrorIs(t, a.Merge(NewCountMinSketch[string](0.1, 0.01, nil)), ErrInvalidSketch, "Merge with different dimensions should fail")
+
+		p := ParallelCollectTo(Range(0, 10_000).Map(func(i int) int { return i % 10 }), CountMinSketchCollector[int](0.01, 0.01, nil), WithConcurrency(4))
+		assert.GreaterOrEqual(t, p.Estimate(7), int64(1000), "Parallel sketch should merge partial counts")
+		assert.Equal(t, int64(10_000), p.Total(), "Parallel sketch total should be complete")
+	})
+
+	t.Run("MarshalRoundTrip", func(t *testing.T) {
+		t.Parallel()
+		c := NewCountMinSketchWithSize[string](100, 4, nil)
+		c.AddN("a", 10)
+		c.Add("b")
+		data, err := c.MarshalBinary()
+		require.NoError(t, err, "MarshalBinary should succeed")
+
+		var decoded CountMinSketch[string]
+		require.NoError(t, decoded.UnmarshalBinary(data), "UnmarshalBinary should succeed")
+		assert.Equal(t, int64(10), decoded.Estimate("a"), "Decoded sketch should answer queries")
+		assert.Equal(t, int64(11), decoded.Total(), "Decoded total should match")
+
+		assert.ErrorIs(t, decoded.UnmarshalBinary(data[:len(data)-3]), ErrInvalidSketch, "Truncated data should be invalid")
+		hll, _ := NewHyperLogLog(4).MarshalBinary()
+		assert.ErrorIs(t, decoded.UnmarshalBinary(hll), ErrInvalidSketch, "Other sketch kinds should be invalid")
+	})
+
+	t.Run("ZeroValue", func(t *testing.T) {
+		t.Parallel()
+		var c CountMinSketch[string]
+		assert.Equal(t, int64(0), c.Estimate("a"), "Zero value should estimate 0")
+		assert.PanicsWithValue(t, "CountMinSketch.AddN: sketch is not initialized", func() { c.Add("a") })
+		assert.Equal(t, int64(0), c.Total(), "A rejected add should not change the total")
+	})
+}
+
+// --- Bloom Filter Tests ---
+
+func TestBloomFilter(t *testing.T) {
+	t.Parallel()
+	t.Run("NoFalseNegatives", func(t *testing.T) {
+		t.Parallel()
+		b := NewBloomFilter[int](10_000, 0.01, nil)
+		for i := range 10_000 {
+			b.Add(i)
+		}
+		for i := range 10_000 {
+			require.True(t, b.Contains(i), "Added value %d should be contained", i)
+		}
+	})
+
+	t.Run("FalsePositiveRate", func(t *testing.T) {
+		t.Parallel()
+		b := NewBloomFilter[int](10_000, 0.01, nil)
+		assert.Equal(t, 7, b.HashCount(), "Optimal hash count for 1% should be 7")
+		assert.InDelta(t, 95_851, b.BitSize(), 1, "Optimal bit size for 10k values at 1%")
+		for i := range 10_000 {
+			b.Add(i)
+		}
+		fp := 0
+		for i := 10_000; i < 110_000; i++ {
+			if b.Contains(i) {
+				fp++
+			}
+		}
+		assert.InDelta(t, 0.01, float64(fp)/100_000, 0.005, "False-positive rate should be near the target")
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		t.Parallel()
+		b := NewBloomFilter[string](0, 2, nil)
+		b.Add("x")
+		assert.True(t, b.Contains("x"), "Filter with defaults should work")
+		assert.GreaterOrEqual(t, b.BitSize(), 64, "Filter should have at least 64 bits")
+	})
+
+	t.Run("ZeroValue", func(t *testing.T) {
+		t.Parallel()
+		var b BloomFilter[string]
+		assert.False(t, b.Contains("x"), "Zero value filter should contain nothing")
+		assert.Panics(t, func() { b.Add("x") }, "Adding to a zero value filter should panic")
+		assert.Error(t, b.UnmarshalBinary(nil), "Invalid data should fail to decode")
+		assert.False(t, b.Contains("x"), "Failed decode should leave the filter empty")
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		t.Parallel()
+		a := NewBloomFilter[string](100, 0.01, nil)
+		b := NewBloomFilter[string](100, 0.01, nil)
+		a.Add("a")
+		b.Add("b")
+		require.NoError(t, a.Merge(b), "Merge should succeed")
+		assert.True(t, a.Contains("a") && a.Contains("b"), "Merged filter should contain both sides")
+		assert.ErrorIs(t, a.Merge(NewBloomFilter[string](1000, 0.01, nil)), ErrInvalidSketch, "Merge with different size should fail")
+	})
+
+	t.Run("MarshalRoundTrip", func(t *testing.T) {
+		t.Parallel()
+		b := NewBloomFilter[string](1000, 0.01, nil)
+		for _, w := range []string{"alpha", "beta", "gamma"} {
+			b.Add(w)
+		}
+		data, err := b.MarshalBinary()
+		require.NoError(t, err, "MarshalBinary should succeed")
+
+		var decoded BloomFilter[string]
+		require.NoError(t, decoded.UnmarshalBinary(data), "UnmarshalBinary should succeed")
+		assert.True(t, decoded.Contains("beta"), "Decoded filter should contain added values")
+		assert.Equal(t, b.BitSize(), decoded.BitSize(), "Decoded size should match")
+		assert.Equal(t, b.HashCount(), decoded.HashCount(), "Decoded hash count should match")
+
+		assert.ErrorIs(t, decoded.UnmarshalBinary(data[:len(data)-8]), ErrInvalidSketch, "Truncated data should be invalid")
+		assert.ErrorIs(t, decoded.UnmarshalBinary(append(data, 0)), ErrInvalidSketch, "Trailing data should be invalid")
+	})
+
+	t.Run("CollectorAndSemiJoin", func(t *testing.T) {
+		t.Parallel()
+		type order struct {
+			ID     int
+			UserID string
+		}
+		active := CollectTo(Of("u1", "u3"), BloomFilterCollector[string](100, 0.001, nil))
+		orders := Of(order{1, "u1"}, order{2, "u2"}, order{3, "u3"}, order{4, "u4"})
+		result := BloomSemiJoinBy(orders, active, func(o order) string { return o.UserID }).Collect()
+		assert.Equal(t, []order{{1, "u1"}, {3, "u3"}}, result, "BloomSemiJoinBy should keep orders of active users")
+	})
+
+	t.Run("ParallelCollect", func(t *testing.T) {
+		t.Parallel()
+		b := ParallelCollectTo(Range(0, 5000), BloomFilterCollector[int](5000, 0.01, nil), WithConcurrency(4))
+		for i := range 5000 {
+			require.True(t, b.Contains(i), "Merged filter should contain %d", i)
+		}
+	})
+}
+
+func TestScalableBloomFilter(t *testing.T) {
+	t.Parallel()
+	s := NewScalableBloomFilter[int](100, 0.01, nil)
+	added := 0
+	for i := range 20_000 {
+		if s.Add(i) {
+			added++
+		}
+	}
+	assert.False(t, s.Add(5), "Adding an existing value should report false")
+	assert.Greater(t, len(s.filters), 5, "Filter should grow beyond its initial capacity")
+	for i := range 20_000 {
+		require.True(t, s.Contains(i), "Added value %d should be contained", i)
+	}
+	fp := 0
+	for i := 20_000; i < 120_000; i++ {
+		if s.Contains(i) {
+			fp++
+		}
+	}
+	assert.Less(t, float64(fp)/100_000, 0.01, "Overall false-positive rate should stay below the target")
+	assert.Greater(t, added, 19_800, "Few values should be lost to false positives while growing")
+}
+
+func TestDistinctApprox(t *testing.T) {
+	t.Parallel()
+	t.Run("RemovesDuplicates", func(t *testing.T) {
+		t.Parallel()
+		result := DistinctApprox(Of(1, 2, 1, 3, 2, 4), 0.001).Collect()
+		assert.Equal(t, []int{1, 2, 3, 4}, result, "DistinctApprox should remove duplicates in order")
+	})
+
+	t.Run("LargeStream", func(t *testing.T) {
+		t.Parallel()
+		s := Range(0, 200_000).Map(func(i int) int { return i % 50_000 })
+		result := DistinctApprox(s, 0.01).Collect()
+		assert.LessOrEqual(t, len(result), 50_000, "DistinctApprox should never emit duplicates")
+		assert.Greater(t, len(result), 49_500, "DistinctApprox should drop few distinct elements")
+	})
+
+	t.Run("By", func(t *testing.T) {
+		t.Parallel()
+		result := DistinctApproxBy(Of("apple", "avocado", "banana", "blueberry", "cherry"), func(s string) byte { return s[0] }, 0.01).Collect()
+		assert.Equal(t, []string{"apple", "banana", "cherry"}, result, "DistinctApproxBy should dedupe by key")
+	})
+
+	t.Run("EarlyTermination", func(t *testing.T) {
+		t.Parallel()
+		result := DistinctApprox(Iterate(0, func(n int) int { return n + 1 }), 0.01).Limit(3).Collect()
+		assert.Equal(t, []int{0, 1, 2}, result, "DistinctApprox should be lazy")
+	})
+}
@@ -654,6 +654,44 @@ func DistinctUntilChangedBy[T any](s Stream[T], eq func(a, b T) bool) Stream[T]
 	}
 }
 
+// GroupAdjacentBy groups consecutive elements with equal keys into runs and yields each
+// run with its key. Unlike GroupingByCollector, a key that reappears later starts a new run,
+// so the input is typically sorted or clustered by key (e.g. log lines by session id).
+// Each run is yielded as soon as the next key differs; memory is O(run size).
+func GroupAdjacentBy[T any, K comparable](s Stream[T], keyFn func(T) K) Stream[Pair[K, []T]] {
+	return GroupAdjacentByWith(s, keyFn, ToSliceCollector[T]())
+}
+
+// GroupAdjacentByWith groups consecutive elements with equal keys into runs and collects each
+// run with the downstream collector, yielding the key with the run's result.
+// Only the current run's accumulator is kept; once it is done, the rest of the run is skipped.
+func GroupAdjacentByWith[T any, K comparable, A, R any](s Stream[T], keyFn func(T) K, downstream Collector[T, A, R]) Stream[Pair[K, R]] {
+	return Stream[Pair[K, R]]{
+		seq: func(yield func(Pair[K, R]) bool) {
+			var (
+				key     K
+				acc     A
+				started bool
+			)
+			for v := range s.seq {
+				k := keyFn(v)
+				if !started || k != key {
+					if started && !yield(NewPair(key, downstream.Finisher(acc))) {
+						return
+					}
+					key, acc, started = k, downstream.Supplier(), true
+				}
+				if !downstream.done(acc) {
+					acc = downstream.Accumulator(acc, v)
+				}
+			}
+			if started {
+				yield(NewPair(key, downstream.Finisher(acc)))
+			}
+		},
+	}
+}
+
 // TakeLast returns a Stream containing the last n elements.
 // Uses a ring buffer for O(L) time complexity where L is the input length.
 // If n <= 0, returns an empty stream.
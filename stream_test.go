@@ -1755,3 +1755,40 @@ func TestEarlyTerminationDirectSeq(t *testing.T) {
 		assert.Equal(t, expected, result, "Heap path should correctly return first 7 elements")
 	})
 }
+
+func TestGroupAdjacentBy(t *testing.T) {
+	t.Parallel()
+
+	type line struct {
+		session string
+		bytes   int
+	}
+	lines := []line{{"a", 1}, {"a", 2}, {"b", 5}, {"a", 3}, {"a", 4}, {"a", 6}}
+	session := func(l line) string { return l.session }
+
+	t.Run("Runs", func(t *testing.T) {
+		t.Parallel()
+		result := GroupAdjacentBy(Of(1, 1, 2, 3, 3, 3, 1), func(n int) int { return n }).Collect()
+		assert.Equal(t, []Pair[int, []int]{
+			{1, []int{1, 1}}, {2, []int{2}}, {3, []int{3, 3, 3}}, {1, []int{1}},
+		}, result, "GroupAdjacentBy should start a new run when the key changes")
+		assert.Empty(t, GroupAdjacentBy(Empty[int](), func(n int) int { return n }).Collect())
+	})
+
+	t.Run("WithDownstream", func(t *testing.T) {
+		t.Parallel()
+		totals := GroupAdjacentByWith(FromSlice(lines), session,
+			MappingCollector(func(l line) int { return l.bytes }, SummingCollector[int]())).Collect()
+		assert.Equal(t, []Pair[string, int]{{"a", 3}, {"b", 5}, {"a", 13}}, totals, "GroupAdjacentByWith should aggregate each run")
+
+		heads := GroupAdjacentByWith(FromSlice(lines), session, LimitingCollector(2, CountingCollector[line]())).Collect()
+		assert.Equal(t, []Pair[string, int]{{"a", 2}, {"b", 1}, {"a", 2}}, heads, "Done runs should skip their remaining elements")
+	})
+
+	t.Run("LazyOnInfiniteStream", func(t *testing.T) {
+		t.Parallel()
+		naturals := Iterate(0, func(n int) int { return n + 1 })
+		runs := GroupAdjacentBy(naturals, func(n int) int { return n / 3 }).Limit(2).Collect()
+		assert.Equal(t, []Pair[int, []int]{{0, []int{0, 1, 2}}, {1, []int{3, 4, 5}}}, runs, "GroupAdjacentBy should be lazy")
+	})
+}
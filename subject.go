@@ -0,0 +1,314 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// --- Subject (Push-Based Source) ---
+
+// ErrSubjectClosed is returned by Emit after the subject was completed or failed.
+var ErrSubjectClosed = errors.New("streams: subject is closed")
+
+// ErrBufferFull is returned by Emit under OverflowError when the buffer is full.
+var ErrBufferFull = errors.New("streams: subject buffer is full")
+
+// OverflowStrategy decides what Emit does when the subject's buffer is full.
+type OverflowStrategy int
+
+const (
+	// OverflowBlock makes Emit wait until a consumer makes room (backpressure).
+	OverflowBlock OverflowStrategy = iota
+	// OverflowDropOldest discards the oldest buffered element to make room.
+	OverflowDropOldest
+	// OverflowDropNewest discards the element being emitted.
+	OverflowDropNewest
+	// OverflowError makes Emit return ErrBufferFull.
+	OverflowError
+)
+
+// SubjectConfig holds configuration for NewSubject.
+type SubjectConfig struct {
+	BufferSize int              // Maximum number of buffered elements
+	Overflow   OverflowStrategy // What Emit does when the buffer is full
+}
+
+// DefaultSubjectConfig returns the default subject configuration.
+func DefaultSubjectConfig() SubjectConfig {
+	return SubjectConfig{
+		BufferSize: 64,
+		Overflow:   OverflowBlock,
+	}
+}
+
+// SubjectOption is a function that modifies SubjectConfig.
+type SubjectOption func(*SubjectConfig)
+
+// WithSubjectBufferSize sets the maximum number of buffered elements.
+func WithSubjectBufferSize(size int) SubjectOption {
+	return func(c *SubjectConfig) {
+		if size > 0 {
+			c.BufferSize = size
+		}
+	}
+}
+
+// WithOverflow sets the strategy applied when the buffer is full.
+func WithOverflow(strategy OverflowStrategy) SubjectOption {
+	return func(c *SubjectConfig) {
+		c.Overflow = strategy
+	}
+}
+
+// Subject is a push-based source: producers call Emit from any goroutine and consumers
+// iterate Stream or Results. Elements are buffered in a bounded queue and each element is
+// delivered to exactly one consumer; use Share on the stream to deliver to several.
+// Complete or Fail end the consumers' streams once the buffer is drained; StreamCtx and
+// ResultsCtx also end when their context is done.
+//
+//	events := NewSubject[Event](WithOverflow(OverflowDropOldest))
+//	go func() { events.Stream().ForEach(process) }()
+//	http.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+//		_ = events.Emit(parse(r))
+//	})
+type Subject[T any] struct {
+	cfg       SubjectConfig
+	mu        sync.Mutex
+	buf       []T // ring buffer
+	head      int
+	size      int
+	closed    bool
+	err       error
+	dropped   int
+	consumers waitQueue // consumers waiting for an element
+	producers waitQueue // producers waiting for room
+}
+
+// waitQueue is a FIFO of goroutines waiting on a Subject, so that each push or pop
+// wakes a single waiter. All methods must be called with the Subject's mu held.
+type waitQueue struct {
+	waiters []chan struct{}
+}
+
+// add enqueues a new waiter and returns the channel it is woken on.
+func (q *waitQueue) add() chan struct{} {
+	ch := make(chan struct{}, 1)
+	q.waiters = append(q.waiters, ch)
+	return ch
+}
+
+// wakeOne wakes the longest waiting goroutine, if any.
+func (q *waitQueue) wakeOne() {
+	if len(q.waiters) > 0 {
+		q.waiters[0] <- struct{}{}
+		q.waiters = q.waiters[1:]
+	}
+}
+
+// wakeAll wakes every waiting goroutine.
+func (q *waitQueue) wakeAll() {
+	for _, ch := range q.waiters {
+		ch <- struct{}{}
+	}
+	q.waiters = nil
+}
+
+// leave dequeues a waiter that stopped waiting. If it was already woken, the wake-up
+// is passed on to the next waiter so it is not lost.
+func (q *waitQueue) leave(ch chan struct{}) {
+	if i := slices.Index(q.waiters, ch); i >= 0 {
+		q.waiters = slices.Delete(q.waiters, i, i+1)
+		return
+	}
+	q.wakeOne()
+}
+
+// NewSubject creates a Subject with the given options.
+func NewSubject[T any](opts ...SubjectOption) *Subject[T] {
+	cfg := DefaultSubjectConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Subject[T]{
+		cfg: cfg,
+		buf: make([]T, cfg.BufferSize),
+	}
+}
+
+// push appends v to the ring buffer. Must hold mu and have room.
+func (s *Subject[T]) push(v T) {
+	s.buf[(s.head+s.size)%len(s.buf)] = v
+	s.size++
+}
+
+// pop removes the oldest element from the ring buffer. Must hold mu and be non-empty.
+func (s *Subject[T]) pop() T {
+	var zero T
+	v := s.buf[s.head]
+	s.buf[s.head] = zero
+	s.head = (s.head + 1) % len(s.buf)
+	s.size--
+	return v
+}
+
+// Emit adds v to the buffer, applying the overflow strategy if it is full.
+// Returns ErrSubjectClosed after Complete or Fail, and ErrBufferFull under OverflowError.
+// Under OverflowBlock, Emit waits for room; use EmitCtx to bound the wait.
+func (s *Subject[T]) Emit(v T) error {
+	return s.EmitCtx(context.Background(), v)
+}
+
+// EmitCtx is like Emit but stops waiting for room and returns ctx.Err() when ctx is done.
+func (s *Subject[T]) EmitCtx(ctx context.Context, v T) error {
+	for {
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			return ErrSubjectClosed
+		}
+		if s.size < len(s.buf) {
+			s.push(v)
+			s.consumers.wakeOne()
+			s.mu.Unlock()
+			return nil
+		}
+		switch s.cfg.Overflow {
+		case OverflowDropOldest:
+			s.pop()
+			s.push(v)
+			s.dropped++
+			s.mu.Unlock()
+			return nil
+		case OverflowDropNewest:
+			s.dropped++
+			s.mu.Unlock()
+			return nil
+		case OverflowError:
+			s.mu.Unlock()
+			return ErrBufferFull
+		}
+		wake := s.producers.add()
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			s.mu.Lock()
+			s.producers.leave(wake)
+			s.mu.Unlock()
+			return ctx.Err()
+		case <-wake:
+		}
+	}
+}
+
+// Complete ends the consumers' streams once the buffered elements are consumed.
+// Calls after the first Complete or Fail have no effect.
+func (s *Subject[T]) Complete() {
+	s.Fail(nil)
+}
+
+// Fail ends the consumers' streams with err once the buffered elements are consumed.
+// Results yields err as its last element; Stream ends silently.
+// Fail(nil) is equivalent to Complete. Calls after the first Complete or Fail have no effect.
+func (s *Subject[T]) Fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.err = err
+	s.consumers.wakeAll()
+	s.producers.wakeAll()
+}
+
+// Len returns the number of buffered elements.
+func (s *Subject[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.size
+}
+
+// Dropped returns the number of elements discarded by OverflowDropOldest or OverflowDropNewest.
+func (s *Subject[T]) Dropped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// next waits for the next element. It returns false with the failure error, if any,
+// once the subject is closed and drained, or with ctx.Err() when ctx is done.
+func (s *Subject[T]) next(ctx context.Context) (T, bool, error) {
+	var zero T
+	for {
+		s.mu.Lock()
+		if s.size > 0 {
+			v := s.pop()
+			s.producers.wakeOne()
+			s.mu.Unlock()
+			return v, true, nil
+		}
+		if s.closed {
+			err := s.err
+			s.mu.Unlock()
+			return zero, false, err
+		}
+		wake := s.consumers.add()
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			s.mu.Lock()
+			s.consumers.leave(wake)
+			s.mu.Unlock()
+			return zero, false, ctx.Err()
+		case <-wake:
+		}
+	}
+}
+
+// Results returns a Stream that yields emitted elements as Ok results, waiting for new ones
+// until the subject is completed or failed. After Fail(err), it yields Err(err) last.
+// Concurrent iterations compete for elements; a stopped iteration can be resumed later.
+func (s *Subject[T]) Results() Stream[Result[T]] {
+	return s.ResultsCtx(context.Background())
+}
+
+// ResultsCtx is like Results but also stops waiting when ctx is done, yielding
+// Err(ctx.Err()) last.
+func (s *Subject[T]) ResultsCtx(ctx context.Context) Stream[Result[T]] {
+	return Stream[Result[T]]{
+		seq: func(yield func(Result[T]) bool) {
+			for {
+				// Prioritize context cancellation if both ctx and an element are ready.
+				if err := ctx.Err(); err != nil {
+					yield(Err[T](err))
+					return
+				}
+				v, ok, err := s.next(ctx)
+				if !ok {
+					if err != nil {
+						yield(Err[T](err))
+					}
+					return
+				}
+				if !yield(Ok(v)) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// Stream returns a Stream that yields emitted elements, waiting for new ones until the
+// subject is completed or failed. Use Results to observe the failure error.
+func (s *Subject[T]) Stream() Stream[T] {
+	return TakeUntilErr(s.Results())
+}
+
+// StreamCtx is like Stream but also ends when ctx is done.
+func (s *Subject[T]) StreamCtx(ctx context.Context) Stream[T] {
+	return TakeUntilErr(s.ResultsCtx(ctx))
+}
@@ -0,0 +1,176 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubject(t *testing.T) {
+	t.Parallel()
+
+	t.Run("EmitAndComplete", func(t *testing.T) {
+		t.Parallel()
+		subject := NewSubject[int]()
+		done := make(chan []int)
+		go func() { done <- subject.Stream().Collect() }()
+		for i := range 100 {
+			require.NoError(t, subject.Emit(i))
+		}
+		subject.Complete()
+		assert.Equal(t, Range(0, 100).Collect(), <-done, "Consumers should see every element in order")
+		assert.ErrorIs(t, subject.Emit(1), ErrSubjectClosed, "Emit after Complete should fail")
+	})
+
+	t.Run("ConcurrentProducers", func(t *testing.T) {
+		t.Parallel()
+		subject := NewSubject[int](WithSubjectBufferSize(4))
+		done := make(chan int)
+		go func() { done <- Sum(subject.Stream()) }()
+		var wg sync.WaitGroup
+		for p := range 4 {
+			wg.Go(func() {
+				for i := range 100 {
+					_ = subject.Emit(p*100 + i)
+				}
+			})
+		}
+		wg.Wait()
+		subject.Complete()
+		assert.Equal(t, Sum(Range(0, 400)), <-done)
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		t.Parallel()
+		subject := NewSubject[string]()
+		require.NoError(t, subject.Emit("a"))
+		require.NoError(t, subject.Emit("b"))
+		boom := errors.New("boom")
+		subject.Fail(boom)
+		subject.Complete()
+		values, err := CollectResults(subject.Results())
+		assert.Equal(t, []string{"a", "b"}, values, "Buffered elements should be delivered before the error")
+		assert.ErrorIs(t, err, boom)
+		assert.Empty(t, subject.Stream().Collect())
+	})
+
+	t.Run("ResumeAfterEarlyStop", func(t *testing.T) {
+		t.Parallel()
+		subject := NewSubject[int]()
+		for i := range 5 {
+			require.NoError(t, subject.Emit(i))
+		}
+		subject.Complete()
+		assert.Equal(t, []int{0, 1}, firstN(subject.Stream(), 2))
+		assert.Equal(t, []int{2, 3, 4}, subject.Stream().Collect(), "A later iteration should resume where the last stopped")
+	})
+
+	t.Run("OverflowBlock", func(t *testing.T) {
+		t.Parallel()
+		subject := NewSubject[int](WithSubjectBufferSize(2))
+		require.NoError(t, subject.Emit(1))
+		require.NoError(t, subject.Emit(2))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, subject.EmitCtx(ctx, 3), context.DeadlineExceeded, "Emit should block while the buffer is full")
+
+		emitted := make(chan error)
+		go func() { emitted <- subject.Emit(3) }()
+		assert.Equal(t, []int{1}, firstN(subject.Stream(), 1))
+		require.NoError(t, <-emitted, "Emit should resume once a consumer makes room")
+		subject.Complete()
+		assert.Equal(t, []int{2, 3}, subject.Stream().Collect())
+	})
+
+	t.Run("OverflowDropOldest", func(t *testing.T) {
+		t.Parallel()
+		subject := NewSubject[int](WithSubjectBufferSize(3), WithOverflow(OverflowDropOldest))
+		for i := range 10 {
+			require.NoError(t, subject.Emit(i))
+		}
+		subject.Complete()
+		assert.Equal(t, 3, subject.Len())
+		assert.Equal(t, 7, subject.Dropped())
+		assert.Equal(t, []int{7, 8, 9}, subject.Stream().Collect(), "The newest elements should be kept")
+	})
+
+	t.Run("OverflowDropNewest", func(t *testing.T) {
+		t.Parallel()
+		subject := NewSubject[int](WithSubjectBufferSize(3), WithOverflow(OverflowDropNewest))
+		for i := range 10 {
+			require.NoError(t, subject.Emit(i))
+		}
+		subject.Complete()
+		assert.Equal(t, 7, subject.Dropped())
+		assert.Equal(t, []int{0, 1, 2}, subject.Stream().Collect(), "The oldest elements should be kept")
+	})
+
+	t.Run("OverflowError", func(t *testing.T) {
+		t.Parallel()
+		subject := NewSubject[int](WithSubjectBufferSize(1), WithOverflow(OverflowError))
+		require.NoError(t, subject.Emit(1))
+		assert.ErrorIs(t, subject.Emit(2), ErrBufferFull)
+		subject.Complete()
+		assert.Equal(t, []int{1}, subject.Stream().Collect())
+	})
+
+	t.Run("StreamCtx", func(t *testing.T) {
+		t.Parallel()
+		subject := NewSubject[int]()
+		require.NoError(t, subject.Emit(1))
+		ctx, cancel := context.WithCancel(context.Background())
+		results := make(chan []Result[int])
+		go func() { results <- subject.ResultsCtx(ctx).Collect() }()
+		time.Sleep(20 * time.Millisecond) // let the consumer wait for a second element
+		cancel()
+		got := <-results
+		require.Len(t, got, 2)
+		assert.Equal(t, 1, got[0].Value(), "Buffered elements should be delivered before cancellation")
+		assert.ErrorIs(t, got[1].Error(), context.Canceled, "ResultsCtx should end with the context error")
+
+		assert.Empty(t, subject.StreamCtx(ctx).Collect(), "StreamCtx should end when the context is done")
+		require.NoError(t, subject.Emit(2), "A cancelled consumer should not affect the subject")
+		subject.Complete()
+		assert.Equal(t, []int{2}, subject.Stream().Collect())
+	})
+
+	t.Run("ManyWaiters", func(t *testing.T) {
+		t.Parallel()
+		subject := NewSubject[int](WithSubjectBufferSize(1))
+		const n = 50
+		got := make(chan int, n)
+		var wg sync.WaitGroup
+		for range n {
+			wg.Go(func() {
+				for _, v := range firstN(subject.Stream(), 1) {
+					got <- v
+				}
+			})
+		}
+		for i := range n {
+			wg.Go(func() { require.NoError(t, subject.Emit(i)) })
+		}
+		wg.Wait() // each push and pop wakes one waiter; none may be left waiting
+		close(got)
+		assert.Equal(t, Sum(Range(0, n)), Sum(FromChannel(got)), "Every element should be delivered exactly once")
+	})
+
+	t.Run("Pipeline", func(t *testing.T) {
+		t.Parallel()
+		subject := NewSubject[int]()
+		done := make(chan []int)
+		go func() {
+			done <- subject.Stream().Filter(func(n int) bool { return n%2 == 0 }).Limit(3).Collect()
+		}()
+		for i := range 10 {
+			require.NoError(t, subject.Emit(i))
+		}
+		assert.Equal(t, []int{0, 2, 4}, <-done, "A subject should feed a lazy pipeline")
+	})
+}